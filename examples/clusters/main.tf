@@ -17,4 +17,10 @@ data "pinot_clusters" "edu" {}
 
 output "edu_clusters" {
   value = data.pinot_clusters.edu
-}
\ No newline at end of file
+}
+check "cluster_health" {
+  assert {
+    condition     = data.pinot_clusters.edu.healthy
+    error_message = "The Pinot cluster is unhealthy: ${data.pinot_clusters.edu.health_status}, dead instances: ${join(", ", data.pinot_clusters.edu.dead_instances)}"
+  }
+}
@@ -15,4 +15,13 @@ data "pinot_instances" "test" {}
 
 output "test_instances" {
   value = data.pinot_instances.test
-}
\ No newline at end of file
+}
+data "pinot_instances" "offline_servers" {
+  type    = "SERVER"
+  tag     = "DefaultTenant_OFFLINE"
+  enabled = true
+}
+
+output "offline_servers" {
+  value = [for instance in data.pinot_instances.offline_servers.instances : instance.instance_name]
+}
@@ -20,10 +20,21 @@ data "pinot_segments" "airline" {
   table_name = "airlineStats"
 }
 
+data "pinot_segments" "airline_2014" {
+  table_name   = "airlineStats"
+  from_time_ms = 1388534400000
+  to_time_ms   = 1420070399999
+  statuses     = ["UPLOADED", "DONE"]
+}
+
 output "github_segments" {
   value = data.pinot_segments.github
 }
 
 output "airline_segments" {
   value = data.pinot_segments.airline
-}
\ No newline at end of file
+}
+
+output "airline_2014_docs" {
+  value = sum(concat([0], [for segment in data.pinot_segments.airline_2014.offline_segments : segment.total_docs]))
+}
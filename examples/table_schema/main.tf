@@ -37,3 +37,15 @@ resource "pinot_schema" "block_schema" {
     not_null  = true
   }]
 }
+
+data "pinot_schema" "block_schema" {
+  schema_name = pinot_schema.block_schema.schema_name
+}
+
+data "pinot_schemas" "ethereum" {
+  name_pattern = "^ethereum_"
+}
+
+output "ethereum_schema_names" {
+  value = [for schema in data.pinot_schemas.ethereum.schemas : schema.schema_name]
+}
@@ -146,3 +146,14 @@ resource "pinot_table" "realtime_table" {
 
   depends_on = [pinot_schema.realtime_table_schema]
 }
+
+data "pinot_table" "realtime" {
+  name = "realtime_ethereum_mainnet_block_headers"
+  type = "REALTIME"
+
+  depends_on = [pinot_table.realtime_table]
+}
+
+output "realtime_time_column" {
+  value = data.pinot_table.realtime.segments_config.time_column_name
+}
@@ -16,4 +16,11 @@ data "pinot_tenants" "edu" {}
 
 output "edu_tenants" {
   value = data.pinot_tenants.edu
-}
\ No newline at end of file
+}
+data "pinot_tenants" "default" {
+  tenant_name = "DefaultTenant"
+}
+
+output "default_tenant_servers" {
+  value = data.pinot_tenants.default.tenants[0].num_server_instances
+}
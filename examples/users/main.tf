@@ -17,14 +17,41 @@ resource "pinot_user" "test" {
   password  = "password"
   component = "BROKER"
   role      = "USER"
+}
 
-  lifecycle {
-    ignore_changes = [password]
-  }
+resource "pinot_user" "analyst" {
+  username    = "analyst"
+  password    = "password"
+  component   = "BROKER"
+  role        = "USER"
+  tables      = ["marketing_events", "marketing_campaigns"]
+  permissions = ["READ"]
+}
+
+resource "pinot_user" "service" {
+  username         = "ingestion"
+  password_wo      = var.ingestion_password
+  password_version = 1
+  components       = ["CONTROLLER", "BROKER"]
+  role             = "ADMIN"
+}
+
+variable "ingestion_password" {
+  type      = string
+  sensitive = true
 }
 
 data "pinot_users" "edu" {}
 
+data "pinot_users" "analysts" {
+  component        = "BROKER"
+  username_pattern = "^analyst"
+}
+
+output "analyst_tables" {
+  value = { for user in data.pinot_users.analysts.users : user.username => user.tables }
+}
+
 output "edu_users" {
   value = data.pinot_users.edu
 }
\ No newline at end of file
@@ -4,38 +4,26 @@ import (
 	"context"
 	"github.com/azaurus1/go-pinot-api/model"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/customtypes"
 	"terraform-provider-pinot/internal/models"
 )
 
 func SetStateFromTable(ctx context.Context, state *models.TableResourceModel, table *model.Table) {
 
-	state.TableName = types.StringValue(table.TableName)
+	state.TableName = types.StringValue(customtypes.TrimTableTypeSuffix(table.TableName))
 	state.TableType = types.StringValue(table.TableType)
 
 	state.TenantsConfig = &models.TenantsConfig{
-		Broker: types.StringValue(table.Tenants.Broker),
-		Server: types.StringValue(table.Tenants.Server),
+		Broker:            types.StringValue(table.Tenants.Broker),
+		Server:            types.StringValue(table.Tenants.Server),
+		TagOverrideConfig: types.MapNull(types.StringType),
 	}
 
 	state.SegmentsConfig = convertSegmentsConfig(table)
 	state.TableIndexConfig = convertTableIndexConfig(ctx, table)
 
-	var ingestionTransformConfigs []*models.TransformConfig
-	for _, transformConfig := range table.IngestionConfig.TransformConfigs {
-		ingestionTransformConfigs = append(ingestionTransformConfigs, &models.TransformConfig{
-			ColumnName:        types.StringValue(transformConfig.ColumnName),
-			TransformFunction: types.StringValue(transformConfig.TransformFunction),
-		})
-	}
-
-	state.IngestionConfig = &models.IngestionConfig{
-		SegmentTimeValueCheck: types.BoolValue(table.IngestionConfig.SegmentTimeValueCheck),
-		RowTimeValueCheck:     types.BoolValue(table.IngestionConfig.RowTimeValueCheck),
-		ContinueOnError:       types.BoolValue(table.IngestionConfig.ContinueOnError),
-		StreamIngestionConfig: &models.StreamIngestionConfig{
-			StreamConfigMaps: table.IngestionConfig.StreamIngestionConfig.StreamConfigMaps,
-		},
-		TransformConfigs: ingestionTransformConfigs,
+	if table.IngestionConfig != nil {
+		state.IngestionConfig = convertIngestionConfig(table)
 	}
 
 	var tierConfigs []*models.TierConfig
@@ -45,6 +33,7 @@ func SetStateFromTable(ctx context.Context, state *models.TableResourceModel, ta
 			StorageType:         types.StringValue(tierConfig.StorageType),
 			SegmentSelectorType: types.StringValue(tierConfig.SegmentSelectorType),
 			SegmentAge:          types.StringValue(tierConfig.SegmentAge),
+			SegmentList:         types.ListNull(types.StringType),
 			ServerTag:           types.StringValue(tierConfig.ServerTag),
 		})
 	}
@@ -53,33 +42,74 @@ func SetStateFromTable(ctx context.Context, state *models.TableResourceModel, ta
 
 	state.IsDimTable = types.BoolValue(table.IsDimTable)
 
-	state.Metadata = &models.Metadata{
-		CustomConfigs: table.Metadata.CustomConfigs,
+	if table.UpsertConfig != nil {
+		state.UpsertConfig = convertUpsertConfig(ctx, table)
+	}
+
+	if table.Metadata != nil {
+		state.Metadata = &models.Metadata{
+			CustomConfigs: table.Metadata.CustomConfigs,
+		}
 	}
 
 }
 
-func convertSegmentPartitionConfig(table *model.Table) *models.SegmentPartitionConfig {
+func convertIngestionConfig(table *model.Table) *models.IngestionConfig {
 
-	segmentPartitionConfig := &models.SegmentPartitionConfig{}
+	var ingestionTransformConfigs []*models.TransformConfig
+	for _, transformConfig := range table.IngestionConfig.TransformConfigs {
+		ingestionTransformConfigs = append(ingestionTransformConfigs, &models.TransformConfig{
+			ColumnName:        types.StringValue(transformConfig.ColumnName),
+			TransformFunction: types.StringValue(transformConfig.TransformFunction),
+		})
+	}
 
-	if table.TableIndexConfig.SegmentPartitionConfig != nil {
+	ingestionConfig := models.IngestionConfig{
+		SegmentTimeValueCheck: types.BoolValue(table.IngestionConfig.SegmentTimeValueCheck),
+		RowTimeValueCheck:     types.BoolValue(table.IngestionConfig.RowTimeValueCheck),
+		ContinueOnError:       types.BoolValue(table.IngestionConfig.ContinueOnError),
+		TransformConfigs:      ingestionTransformConfigs,
+	}
 
-		segmentPartitionMapConfig := map[string]map[string]string{}
-		for key, value := range table.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap {
-			segmentPartitionMapConfig[key] = map[string]string{
-				"functionName":  value.FunctionName,
-				"numPartitions": string(rune(value.NumPartitions)),
-			}
+	if table.IngestionConfig.StreamIngestionConfig != nil {
+		ingestionConfig.StreamIngestionConfig = &models.StreamIngestionConfig{
+			StreamConfigMaps: table.IngestionConfig.StreamIngestionConfig.StreamConfigMaps,
 		}
+	}
 
-		segmentPartitionConfig.ColumnPartitionMap = segmentPartitionMapConfig
+	return &ingestionConfig
+}
 
-	} else {
+func convertUpsertConfig(ctx context.Context, table *model.Table) *models.UpsertConfig {
+
+	upsertConfig := models.UpsertConfig{
+		Mode:                    types.StringValue(table.UpsertConfig.Mode),
+		PartialUpsertStrategies: types.MapNull(types.StringType),
+	}
+
+	if len(table.UpsertConfig.PartialUpsertStrategies) > 0 {
+		upsertConfig.PartialUpsertStrategies, _ = types.MapValueFrom(ctx, types.StringType, table.UpsertConfig.PartialUpsertStrategies)
+	}
+
+	return &upsertConfig
+}
+
+func convertSegmentPartitionConfig(table *model.Table) *models.SegmentPartitionConfig {
+
+	if table.TableIndexConfig.SegmentPartitionConfig == nil {
 		return nil
 	}
 
-	return segmentPartitionConfig
+	columnPartitionMap := map[string]models.ColumnPartitionConfig{}
+	for column, partitionConfig := range table.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap {
+		columnPartitionMap[column] = models.ColumnPartitionConfig{
+			FunctionName:   types.StringValue(partitionConfig.FunctionName),
+			NumPartitions:  types.Int64Value(int64(partitionConfig.NumPartitions)),
+			FunctionConfig: types.MapNull(types.StringType),
+		}
+	}
+
+	return &models.SegmentPartitionConfig{ColumnPartitionMap: columnPartitionMap}
 }
 
 func convertTableIndexConfig(ctx context.Context, table *model.Table) *models.TableIndexConfig {
@@ -99,7 +129,7 @@ func convertTableIndexConfig(ctx context.Context, table *model.Table) *models.Ta
 		OptimizeDictionary:                         types.BoolValue(table.TableIndexConfig.OptimizeDictionary),
 		OptimizeDictionaryForMetrics:               types.BoolValue(table.TableIndexConfig.OptimizeDictionaryForMetrics),
 		NoDictionarySizeRatioThreshold:             types.Float64Value(table.TableIndexConfig.NoDictionarySizeRatioThreshold),
-		StarTreeIndexConfigs:                       convertStarTreeIndexConfigs(ctx, table),
+		StarTreeIndexConfigs:                       convertStarTreeIndexConfigs(ctx, table.TableIndexConfig.StarTreeIndexConfigs),
 		AggregateMetrics:                           types.BoolValue(table.TableIndexConfig.AggregateMetrics),
 		SegmentPartitionConfig:                     convertSegmentPartitionConfig(table),
 		RangeIndexVersion:                          types.Int64Value(int64(table.TableIndexConfig.RangeIndexVersion)),
@@ -114,11 +144,11 @@ func convertTableIndexConfig(ctx context.Context, table *model.Table) *models.Ta
 	return &indexConfig
 }
 
-func convertStarTreeIndexConfigs(ctx context.Context, table *model.Table) []*models.StarTreeIndexConfigs {
+func convertStarTreeIndexConfigs(ctx context.Context, starConfigs []*model.StarTreeIndexConfig) []*models.StarTreeIndexConfigs {
 
 	var starTreeIndexConfigs []*models.StarTreeIndexConfigs
 
-	for _, starConfig := range table.TableIndexConfig.StarTreeIndexConfigs {
+	for _, starConfig := range starConfigs {
 
 		dimensionSplitOrder, _ := types.ListValueFrom(ctx, types.StringType, starConfig.DimensionsSplitOrder)
 		functionColumnPairs, _ := types.ListValueFrom(ctx, types.StringType, starConfig.FunctionColumnPairs)
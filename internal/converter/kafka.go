@@ -0,0 +1,143 @@
+package converter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/models"
+)
+
+const (
+	kafkaDecoderPropertyPrefix = "stream.kafka.decoder.prop."
+	flushThresholdRowsKey      = "realtime.segment.flush.threshold.rows"
+)
+
+// kafkaSecurityPropertyPrefixes are the prefixes of the Kafka client properties that go in security_properties.
+var kafkaSecurityPropertyPrefixes = []string{"ssl.", "sasl."}
+
+// kafkaStreamConfigKeys maps the string attributes of the kafka block to their Pinot stream config keys.
+var kafkaStreamConfigKeys = []struct {
+	key   string
+	value func(kafka *models.KafkaStreamConfig) *types.String
+}{
+	{"stream.kafka.topic.name", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.Topic }},
+	{"stream.kafka.broker.list", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.BrokerList }},
+	{"stream.kafka.consumer.type", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.ConsumerType }},
+	{"stream.kafka.consumer.factory.class.name", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.ConsumerFactoryClass }},
+	{"stream.kafka.decoder.class.name", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.DecoderClass }},
+	{"stream.kafka.consumer.prop.auto.offset.reset", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.OffsetCriteria }},
+	{"realtime.segment.flush.threshold.time", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.FlushThresholdTime }},
+	{"realtime.segment.flush.threshold.segment.size", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.FlushThresholdSegmentSize }},
+	{"security.protocol", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.SecurityProtocol }},
+	{"sasl.mechanism", func(kafka *models.KafkaStreamConfig) *types.String { return &kafka.SaslMechanism }},
+}
+
+// KafkaStreamConfigMap converts the kafka block to the Pinot stream config it stands for.
+func KafkaStreamConfigMap(ctx context.Context, kafka *models.KafkaStreamConfig) map[string]string {
+
+	streamConfig := map[string]string{"streamType": "kafka"}
+
+	for _, streamConfigKey := range kafkaStreamConfigKeys {
+		value := streamConfigKey.value(kafka)
+		if !value.IsNull() && !value.IsUnknown() {
+			streamConfig[streamConfigKey.key] = value.ValueString()
+		}
+	}
+
+	if !kafka.FlushThresholdRows.IsNull() && !kafka.FlushThresholdRows.IsUnknown() {
+		streamConfig[flushThresholdRowsKey] = strconv.FormatInt(kafka.FlushThresholdRows.ValueInt64(), 10)
+	}
+
+	var decoderProperties map[string]string
+	kafka.DecoderProperties.ElementsAs(ctx, &decoderProperties, false)
+	for key, value := range decoderProperties {
+		streamConfig[kafkaDecoderPropertyPrefix+key] = value
+	}
+
+	var securityProperties map[string]string
+	kafka.SecurityProperties.ElementsAs(ctx, &securityProperties, false)
+	for key, value := range securityProperties {
+		streamConfig[key] = value
+	}
+
+	return streamConfig
+}
+
+// SplitKafkaStreamConfig moves the keys the kafka block covers out of the first stream config map and into the
+// kafka block, leaving the remaining keys in stream_config_maps.
+func SplitKafkaStreamConfig(ctx context.Context, streamIngestionConfig *models.StreamIngestionConfig) {
+
+	if len(streamIngestionConfig.StreamConfigMaps) == 0 || streamIngestionConfig.StreamConfigMaps[0]["streamType"] != "kafka" {
+		return
+	}
+
+	remaining := map[string]string{}
+	for key, value := range streamIngestionConfig.StreamConfigMaps[0] {
+		remaining[key] = value
+	}
+	delete(remaining, "streamType")
+
+	kafka := models.KafkaStreamConfig{
+		FlushThresholdRows: types.Int64Null(),
+	}
+
+	for _, streamConfigKey := range kafkaStreamConfigKeys {
+		value, ok := remaining[streamConfigKey.key]
+		*streamConfigKey.value(&kafka) = types.StringNull()
+		if ok {
+			*streamConfigKey.value(&kafka) = types.StringValue(value)
+			delete(remaining, streamConfigKey.key)
+		}
+	}
+
+	if value, ok := remaining[flushThresholdRowsKey]; ok {
+		if rows, err := strconv.ParseInt(value, 10, 64); err == nil {
+			kafka.FlushThresholdRows = types.Int64Value(rows)
+			delete(remaining, flushThresholdRowsKey)
+		}
+	}
+
+	decoderProperties := map[string]string{}
+	securityProperties := map[string]string{}
+	for key, value := range remaining {
+		if strings.HasPrefix(key, kafkaDecoderPropertyPrefix) {
+			decoderProperties[strings.TrimPrefix(key, kafkaDecoderPropertyPrefix)] = value
+			delete(remaining, key)
+			continue
+		}
+		if IsKafkaSecurityProperty(key) {
+			securityProperties[key] = value
+			delete(remaining, key)
+		}
+	}
+
+	kafka.DecoderProperties = stringMapValue(ctx, decoderProperties)
+	kafka.SecurityProperties = stringMapValue(ctx, securityProperties)
+
+	streamIngestionConfig.Kafka = &kafka
+
+	streamConfigMaps := streamIngestionConfig.StreamConfigMaps[1:]
+	if len(remaining) > 0 {
+		streamConfigMaps = append([]map[string]string{remaining}, streamConfigMaps...)
+	}
+
+	streamIngestionConfig.StreamConfigMaps = nil
+	if len(streamConfigMaps) > 0 {
+		streamIngestionConfig.StreamConfigMaps = streamConfigMaps
+	}
+}
+
+// IsKafkaSecurityProperty reports whether key is a Kafka client security property that belongs in security_properties.
+func IsKafkaSecurityProperty(key string) bool {
+	if key == "sasl.mechanism" {
+		return false
+	}
+	for _, prefix := range kafkaSecurityPropertyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
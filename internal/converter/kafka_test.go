@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"terraform-provider-pinot/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKafkaStreamConfigRoundTrip(t *testing.T) {
+
+	ctx := context.Background()
+
+	decoderProperties, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"schema.registry.rest.url": "http://registry:8081"})
+	securityProperties, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"ssl.truststore.location": "/etc/kafka/truststore.jks"})
+
+	kafka := models.KafkaStreamConfig{
+		Topic:                     types.StringValue("events"),
+		BrokerList:                types.StringValue("kafka:9092"),
+		ConsumerType:              types.StringValue("lowlevel"),
+		ConsumerFactoryClass:      types.StringNull(),
+		DecoderClass:              types.StringValue("org.apache.pinot.plugin.inputformat.json.JSONMessageDecoder"),
+		DecoderProperties:         decoderProperties,
+		OffsetCriteria:            types.StringValue("smallest"),
+		FlushThresholdRows:        types.Int64Value(0),
+		FlushThresholdTime:        types.StringNull(),
+		FlushThresholdSegmentSize: types.StringValue("200M"),
+		SecurityProtocol:          types.StringValue("SSL"),
+		SaslMechanism:             types.StringNull(),
+		SecurityProperties:        securityProperties,
+	}
+
+	streamConfig := KafkaStreamConfigMap(ctx, &kafka)
+
+	expected := map[string]string{
+		"streamType":                                         "kafka",
+		"stream.kafka.topic.name":                            "events",
+		"stream.kafka.broker.list":                           "kafka:9092",
+		"stream.kafka.consumer.type":                         "lowlevel",
+		"stream.kafka.decoder.class.name":                    "org.apache.pinot.plugin.inputformat.json.JSONMessageDecoder",
+		"stream.kafka.decoder.prop.schema.registry.rest.url": "http://registry:8081",
+		"stream.kafka.consumer.prop.auto.offset.reset":       "smallest",
+		"realtime.segment.flush.threshold.rows":              "0",
+		"realtime.segment.flush.threshold.segment.size":      "200M",
+		"security.protocol":                                  "SSL",
+		"ssl.truststore.location":                            "/etc/kafka/truststore.jks",
+	}
+
+	if !reflect.DeepEqual(streamConfig, expected) {
+		t.Fatalf("expected %v, got %v", expected, streamConfig)
+	}
+
+	streamConfig["stream.kafka.metadata.populate"] = "true"
+	streamIngestionConfig := models.StreamIngestionConfig{StreamConfigMaps: []map[string]string{streamConfig}}
+
+	SplitKafkaStreamConfig(ctx, &streamIngestionConfig)
+
+	if !reflect.DeepEqual(*streamIngestionConfig.Kafka, kafka) {
+		t.Errorf("expected %+v, got %+v", kafka, *streamIngestionConfig.Kafka)
+	}
+
+	remaining := []map[string]string{{"stream.kafka.metadata.populate": "true"}}
+	if !reflect.DeepEqual(streamIngestionConfig.StreamConfigMaps, remaining) {
+		t.Errorf("expected %v, got %v", remaining, streamIngestionConfig.StreamConfigMaps)
+	}
+}
@@ -0,0 +1,750 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/azaurus1/go-pinot-api/model"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-pinot/internal/models"
+)
+
+// unmodelledKeys are the table config sections converted from the table config itself. The pinot model only
+// covers part of them and fails on values it types differently, e.g. a boolean inverted.enabled or a numeric timeoutMs.
+var unmodelledKeys = map[string]bool{
+	"fieldConfigList": true,
+	"routing":         true,
+	"query":           true,
+	"quota":           true,
+}
+
+// SetStateFromTableConfig sets state from a table config as returned by the controller,
+// including the fields the pinot model does not cover.
+func SetStateFromTableConfig(ctx context.Context, state *models.TableResourceModel, tableConfig map[string]any) error {
+
+	modelledConfig := make(map[string]any, len(tableConfig))
+	for key, value := range tableConfig {
+		if !unmodelledKeys[key] {
+			modelledConfig[key] = value
+		}
+	}
+
+	var table model.Table
+	err := decode(modelledConfig, &table)
+	if err != nil {
+		return err
+	}
+
+	SetStateFromTable(ctx, state, &table)
+
+	if state.TableIndexConfig != nil && state.TableIndexConfig.SegmentPartitionConfig != nil {
+		err = setFunctionConfigs(ctx, state.TableIndexConfig.SegmentPartitionConfig, lookup(tableConfig, "tableIndexConfig", "segmentPartitionConfig"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if state.TableIndexConfig != nil {
+		state.TableIndexConfig.TierOverwrites, err = convertTierOverwrites(ctx, lookup(tableConfig, "tableIndexConfig", "tierOverwrites"))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = setTierConfigs(ctx, state.TierConfigs, tableConfig["tierConfigs"])
+	if err != nil {
+		return err
+	}
+
+	if state.IngestionConfig != nil {
+		err = setIngestionConfigs(ctx, state.IngestionConfig, tableConfig["ingestionConfig"])
+		if err != nil {
+			return err
+		}
+	}
+
+	state.TaskConfig, err = convertTaskConfig(ctx, lookup(tableConfig, "task", "taskTypeConfigsMap"))
+	if err != nil {
+		return err
+	}
+
+	state.InstanceAssignmentConfigMap, err = convertInstanceAssignmentConfigMap(ctx, tableConfig["instanceAssignmentConfigMap"])
+	if err != nil {
+		return err
+	}
+
+	state.SegmentAssignmentConfigMap, err = convertSegmentAssignmentConfigMap(tableConfig["segmentAssignmentConfigMap"])
+	if err != nil {
+		return err
+	}
+
+	var instancePartitionsMap map[string]string
+	err = decode(tableConfig["instancePartitionsMap"], &instancePartitionsMap)
+	if err != nil {
+		return err
+	}
+	state.InstancePartitionsMap = stringMapValue(ctx, instancePartitionsMap)
+
+	state.DimensionTableConfig, err = convertDimensionTableConfig(tableConfig["dimensionTableConfig"])
+	if err != nil {
+		return err
+	}
+
+	state.DedupConfig, err = convertDedupConfig(tableConfig["dedupConfig"])
+	if err != nil {
+		return err
+	}
+
+	state.Routing, err = convertRoutingConfig(ctx, tableConfig["routing"])
+	if err != nil {
+		return err
+	}
+
+	state.Query, err = convertQueryConfig(ctx, tableConfig["query"])
+	if err != nil {
+		return err
+	}
+
+	state.Quota, err = convertQuotaConfig(tableConfig["quota"])
+	if err != nil {
+		return err
+	}
+
+	state.FieldConfigList, err = convertFieldConfigList(ctx, tableConfig["fieldConfigList"])
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// convertTierOverwrites converts the per tier index overrides of the table index config.
+func convertTierOverwrites(ctx context.Context, value any) (map[string]models.TierOverwrite, error) {
+
+	var config map[string]struct {
+		StarTreeIndexConfigs []*model.StarTreeIndexConfig `json:"starTreeIndexConfigs"`
+		NoDictionaryColumns  []string                     `json:"noDictionaryColumns"`
+	}
+	err := decode(value, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config) == 0 {
+		return nil, nil
+	}
+
+	tierOverwrites := make(map[string]models.TierOverwrite, len(config))
+	for tier, tierOverwrite := range config {
+		tierOverwrites[tier] = models.TierOverwrite{
+			StarTreeIndexConfigs: convertStarTreeIndexConfigs(ctx, tierOverwrite.StarTreeIndexConfigs),
+			NoDictionaryColumns:  stringListValue(ctx, tierOverwrite.NoDictionaryColumns),
+		}
+	}
+
+	return tierOverwrites, nil
+}
+
+// setTierConfigs sets the segment list of the fixed segment selector, which the pinot model does not cover,
+// and clears the segment age the fixed selector leaves out.
+func setTierConfigs(ctx context.Context, tierConfigs []*models.TierConfig, value any) error {
+
+	var config []struct {
+		SegmentList []string `json:"segmentList"`
+	}
+	err := decode(value, &config)
+	if err != nil {
+		return err
+	}
+
+	for i, tierConfig := range tierConfigs {
+		if i >= len(config) {
+			break
+		}
+		tierConfig.SegmentList = stringListValue(ctx, config[i].SegmentList)
+		if tierConfig.SegmentAge.ValueString() == "" {
+			tierConfig.SegmentAge = types.StringNull()
+		}
+	}
+
+	return nil
+}
+
+// setIngestionConfigs sets the parts of the ingestion config the pinot model does not cover.
+func setIngestionConfigs(ctx context.Context, ingestionConfig *models.IngestionConfig, value any) error {
+
+	var config struct {
+		BatchIngestionConfig *struct {
+			SegmentIngestionType      *string             `json:"segmentIngestionType"`
+			SegmentIngestionFrequency *string             `json:"segmentIngestionFrequency"`
+			ConsistentDataPush        *bool               `json:"consistentDataPush"`
+			BatchConfigMaps           []map[string]string `json:"batchConfigMaps"`
+		} `json:"batchIngestionConfig"`
+		FilterConfig *struct {
+			FilterFunction string `json:"filterFunction"`
+		} `json:"filterConfig"`
+		ComplexTypeConfig *struct {
+			FieldsToUnnest              []string          `json:"fieldsToUnnest"`
+			Delimiter                   *string           `json:"delimiter"`
+			CollectionNotUnnestedToJSON *string           `json:"collectionNotUnnestedToJson"`
+			PrefixesToRename            map[string]string `json:"prefixesToRename"`
+		} `json:"complexTypeConfig"`
+		AggregationConfigs []struct {
+			ColumnName          string `json:"columnName"`
+			AggregationFunction string `json:"aggregationFunction"`
+		} `json:"aggregationConfigs"`
+	}
+
+	err := decode(value, &config)
+	if err != nil {
+		return err
+	}
+
+	if batchIngestionConfig := config.BatchIngestionConfig; batchIngestionConfig != nil {
+		ingestionConfig.BatchIngestionConfig = &models.BatchIngestionConfig{
+			SegmentIngestionType:      types.StringPointerValue(batchIngestionConfig.SegmentIngestionType),
+			SegmentIngestionFrequency: types.StringPointerValue(batchIngestionConfig.SegmentIngestionFrequency),
+			ConsistentDataPush:        types.BoolPointerValue(batchIngestionConfig.ConsistentDataPush),
+		}
+		if len(batchIngestionConfig.BatchConfigMaps) > 0 {
+			ingestionConfig.BatchIngestionConfig.BatchConfigMaps = batchIngestionConfig.BatchConfigMaps
+		}
+	}
+
+	if config.FilterConfig != nil {
+		ingestionConfig.FilterConfig = &models.FilterConfig{
+			FilterFunction: types.StringValue(config.FilterConfig.FilterFunction),
+		}
+	}
+
+	if complexTypeConfig := config.ComplexTypeConfig; complexTypeConfig != nil {
+		ingestionConfig.ComplexTypeConfig = &models.ComplexTypeConfig{
+			FieldsToUnnest:              stringListValue(ctx, complexTypeConfig.FieldsToUnnest),
+			Delimiter:                   types.StringPointerValue(complexTypeConfig.Delimiter),
+			CollectionNotUnnestedToJSON: types.StringPointerValue(complexTypeConfig.CollectionNotUnnestedToJSON),
+			PrefixesToRename:            stringMapValue(ctx, complexTypeConfig.PrefixesToRename),
+		}
+	}
+
+	for _, aggregationConfig := range config.AggregationConfigs {
+		ingestionConfig.AggregationConfigs = append(ingestionConfig.AggregationConfigs, &models.IngestionAggregationConfig{
+			ColumnName:          types.StringValue(aggregationConfig.ColumnName),
+			AggregationFunction: types.StringValue(aggregationConfig.AggregationFunction),
+		})
+	}
+
+	return nil
+}
+
+func convertTaskConfig(ctx context.Context, value any) (types.Map, error) {
+
+	taskConfigType := types.MapType{ElemType: types.StringType}
+
+	var taskTypeConfigsMap map[string]map[string]string
+	err := decode(value, &taskTypeConfigsMap)
+	if err != nil {
+		return types.MapNull(taskConfigType), err
+	}
+
+	if len(taskTypeConfigsMap) == 0 {
+		return types.MapNull(taskConfigType), nil
+	}
+
+	taskConfig, _ := types.MapValueFrom(ctx, taskConfigType, taskTypeConfigsMap)
+	return taskConfig, nil
+}
+
+func convertInstanceAssignmentConfigMap(ctx context.Context, value any) (map[string]models.InstanceAssignmentConfig, error) {
+
+	var assignmentConfigs map[string]struct {
+		TagPoolConfig *struct {
+			Tag       string  `json:"tag"`
+			PoolBased *bool   `json:"poolBased"`
+			NumPools  *int64  `json:"numPools"`
+			Pools     []int64 `json:"pools"`
+		} `json:"tagPoolConfig"`
+		ReplicaGroupPartitionConfig *struct {
+			ReplicaGroupBased           *bool   `json:"replicaGroupBased"`
+			NumInstances                *int64  `json:"numInstances"`
+			NumReplicaGroups            *int64  `json:"numReplicaGroups"`
+			NumInstancesPerReplicaGroup *int64  `json:"numInstancesPerReplicaGroup"`
+			NumPartitions               *int64  `json:"numPartitions"`
+			NumInstancesPerPartition    *int64  `json:"numInstancesPerPartition"`
+			PartitionColumn             *string `json:"partitionColumn"`
+			MinimizeDataMovement        *bool   `json:"minimizeDataMovement"`
+		} `json:"replicaGroupPartitionConfig"`
+		ConstraintConfig *struct {
+			Constraints []string `json:"constraints"`
+		} `json:"constraintConfig"`
+		PartitionSelector    *string `json:"partitionSelector"`
+		MinimizeDataMovement *bool   `json:"minimizeDataMovement"`
+	}
+
+	err := decode(value, &assignmentConfigs)
+	if err != nil || len(assignmentConfigs) == 0 {
+		return nil, err
+	}
+
+	instanceAssignmentConfigMap := map[string]models.InstanceAssignmentConfig{}
+	for instancePartitionsType, assignmentConfig := range assignmentConfigs {
+
+		instanceAssignmentConfig := models.InstanceAssignmentConfig{
+			PartitionSelector:    types.StringPointerValue(assignmentConfig.PartitionSelector),
+			MinimizeDataMovement: types.BoolPointerValue(assignmentConfig.MinimizeDataMovement),
+		}
+
+		if tagPoolConfig := assignmentConfig.TagPoolConfig; tagPoolConfig != nil {
+			pools := types.ListNull(types.Int64Type)
+			if len(tagPoolConfig.Pools) > 0 {
+				pools, _ = types.ListValueFrom(ctx, types.Int64Type, tagPoolConfig.Pools)
+			}
+			instanceAssignmentConfig.TagPoolConfig = &models.TagPoolConfig{
+				Tag:       types.StringValue(tagPoolConfig.Tag),
+				PoolBased: types.BoolPointerValue(tagPoolConfig.PoolBased),
+				NumPools:  types.Int64PointerValue(tagPoolConfig.NumPools),
+				Pools:     pools,
+			}
+		}
+
+		if partitionConfig := assignmentConfig.ReplicaGroupPartitionConfig; partitionConfig != nil {
+			instanceAssignmentConfig.ReplicaGroupPartitionConfig = &models.ReplicaGroupPartitionConfig{
+				ReplicaGroupBased:           types.BoolPointerValue(partitionConfig.ReplicaGroupBased),
+				NumInstances:                types.Int64PointerValue(partitionConfig.NumInstances),
+				NumReplicaGroups:            types.Int64PointerValue(partitionConfig.NumReplicaGroups),
+				NumInstancesPerReplicaGroup: types.Int64PointerValue(partitionConfig.NumInstancesPerReplicaGroup),
+				NumPartitions:               types.Int64PointerValue(partitionConfig.NumPartitions),
+				NumInstancesPerPartition:    types.Int64PointerValue(partitionConfig.NumInstancesPerPartition),
+				PartitionColumn:             types.StringPointerValue(partitionConfig.PartitionColumn),
+				MinimizeDataMovement:        types.BoolPointerValue(partitionConfig.MinimizeDataMovement),
+			}
+		}
+
+		if assignmentConfig.ConstraintConfig != nil {
+			instanceAssignmentConfig.ConstraintConfig = &models.ConstraintConfig{
+				Constraints: stringListValue(ctx, assignmentConfig.ConstraintConfig.Constraints),
+			}
+		}
+
+		instanceAssignmentConfigMap[instancePartitionsType] = instanceAssignmentConfig
+	}
+
+	return instanceAssignmentConfigMap, nil
+}
+
+func convertSegmentAssignmentConfigMap(value any) (map[string]models.SegmentAssignmentConfig, error) {
+
+	var assignmentConfigs map[string]struct {
+		AssignmentStrategy string `json:"assignmentStrategy"`
+	}
+
+	err := decode(value, &assignmentConfigs)
+	if err != nil || len(assignmentConfigs) == 0 {
+		return nil, err
+	}
+
+	segmentAssignmentConfigMap := map[string]models.SegmentAssignmentConfig{}
+	for instancePartitionsType, assignmentConfig := range assignmentConfigs {
+		segmentAssignmentConfigMap[instancePartitionsType] = models.SegmentAssignmentConfig{
+			AssignmentStrategy: types.StringValue(assignmentConfig.AssignmentStrategy),
+		}
+	}
+
+	return segmentAssignmentConfigMap, nil
+}
+
+func convertDimensionTableConfig(value any) (*models.DimensionTableConfig, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var dimensionTableConfig struct {
+		DisablePreload             *bool `json:"disablePreload"`
+		ErrorOnDuplicatePrimaryKey *bool `json:"errorOnDuplicatePrimaryKey"`
+	}
+
+	err := decode(value, &dimensionTableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DimensionTableConfig{
+		DisablePreload:             types.BoolPointerValue(dimensionTableConfig.DisablePreload),
+		ErrorOnDuplicatePrimaryKey: types.BoolPointerValue(dimensionTableConfig.ErrorOnDuplicatePrimaryKey),
+	}, nil
+}
+
+func convertDedupConfig(value any) (*models.DedupConfig, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var dedupConfig struct {
+		DedupEnabled    bool     `json:"dedupEnabled"`
+		HashFunction    *string  `json:"hashFunction"`
+		MetadataTTL     *float64 `json:"metadataTTL"`
+		DedupTimeColumn *string  `json:"dedupTimeColumn"`
+	}
+
+	err := decode(value, &dedupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DedupConfig{
+		Enabled:         types.BoolValue(dedupConfig.DedupEnabled),
+		HashFunction:    types.StringPointerValue(dedupConfig.HashFunction),
+		MetadataTTL:     types.Float64PointerValue(dedupConfig.MetadataTTL),
+		DedupTimeColumn: types.StringPointerValue(dedupConfig.DedupTimeColumn),
+	}, nil
+}
+
+func convertRoutingConfig(ctx context.Context, value any) (*models.RoutingConfig, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var routing struct {
+		SegmentPrunerTypes   []string `json:"segmentPrunerTypes"`
+		InstanceSelectorType *string  `json:"instanceSelectorType"`
+	}
+
+	err := decode(value, &routing)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RoutingConfig{
+		SegmentPrunerTypes:   stringListValue(ctx, routing.SegmentPrunerTypes),
+		InstanceSelectorType: types.StringPointerValue(routing.InstanceSelectorType),
+	}, nil
+}
+
+func convertQueryConfig(ctx context.Context, value any) (*models.QueryConfig, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	var query struct {
+		TimeoutMs                 *int64            `json:"timeoutMs"`
+		ExpressionOverrideMap     map[string]string `json:"expressionOverrideMap"`
+		MaxQueryResponseSizeBytes *int64            `json:"maxQueryResponseSizeBytes"`
+	}
+
+	err := decode(value, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.QueryConfig{
+		TimeoutMs:                 types.Int64PointerValue(query.TimeoutMs),
+		ExpressionOverrideMap:     stringMapValue(ctx, query.ExpressionOverrideMap),
+		MaxQueryResponseSizeBytes: types.Int64PointerValue(query.MaxQueryResponseSizeBytes),
+	}, nil
+}
+
+func convertQuotaConfig(value any) (*models.QuotaConfig, error) {
+
+	if value == nil {
+		return nil, nil
+	}
+
+	// the controller stores maxQueriesPerSecond as a string
+	var quota struct {
+		Storage             *string `json:"storage"`
+		MaxQueriesPerSecond any     `json:"maxQueriesPerSecond"`
+	}
+
+	err := decode(value, &quota)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaConfig := models.QuotaConfig{
+		Storage:             types.StringPointerValue(quota.Storage),
+		MaxQueriesPerSecond: types.Float64Null(),
+	}
+
+	if quota.MaxQueriesPerSecond != nil {
+		maxQueriesPerSecond, err := strconv.ParseFloat(scalarString(quota.MaxQueriesPerSecond), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota.maxQueriesPerSecond: %w", err)
+		}
+		quotaConfig.MaxQueriesPerSecond = types.Float64Value(maxQueriesPerSecond)
+	}
+
+	return &quotaConfig, nil
+}
+
+func setFunctionConfigs(ctx context.Context, segmentPartitionConfig *models.SegmentPartitionConfig, value any) error {
+
+	var partitionConfig struct {
+		ColumnPartitionMap map[string]struct {
+			FunctionConfig map[string]string `json:"functionConfig"`
+		} `json:"columnPartitionMap"`
+	}
+
+	err := decode(value, &partitionConfig)
+	if err != nil {
+		return err
+	}
+
+	for column, columnPartitionConfig := range segmentPartitionConfig.ColumnPartitionMap {
+		functionConfig := partitionConfig.ColumnPartitionMap[column].FunctionConfig
+		if len(functionConfig) == 0 {
+			continue
+		}
+		columnPartitionConfig.FunctionConfig, _ = types.MapValueFrom(ctx, types.StringType, functionConfig)
+		segmentPartitionConfig.ColumnPartitionMap[column] = columnPartitionConfig
+	}
+
+	return nil
+}
+
+type fieldConfig struct {
+	Name             string            `json:"name"`
+	EncodingType     string            `json:"encodingType"`
+	IndexType        string            `json:"indexType"`
+	IndexTypes       []string          `json:"indexTypes"`
+	CompressionCodec *string           `json:"compressionCodec"`
+	Properties       map[string]string `json:"properties"`
+	TimestampConfig  *struct {
+		Granularities []string `json:"granularities"`
+	} `json:"timestampConfig"`
+	Indexes *struct {
+		Inverted *struct {
+			Enabled any `json:"enabled"`
+		} `json:"inverted"`
+		JSON *struct {
+			MaxLevels               *int64   `json:"maxLevels"`
+			ExcludeArray            *bool    `json:"excludeArray"`
+			DisableCrossArrayUnnest *bool    `json:"disableCrossArrayUnnest"`
+			IncludePaths            []string `json:"includePaths"`
+			ExcludePaths            []string `json:"excludePaths"`
+			ExcludeFields           []string `json:"excludeFields"`
+			IndexPaths              []string `json:"indexPaths"`
+			MaxValueLength          *int64   `json:"maxValueLength"`
+		} `json:"json"`
+		Text *struct {
+			FSTType                   *string  `json:"fst"`
+			RawValue                  *string  `json:"rawValue"`
+			QueryCache                *bool    `json:"queryCache"`
+			UseANDForMultiTermQueries *bool    `json:"useANDForMultiTermQueries"`
+			StopWordsInclude          []string `json:"stopWordsInclude"`
+			StopWordsExclude          []string `json:"stopWordsExclude"`
+			LuceneUseCompoundFile     *bool    `json:"luceneUseCompoundFile"`
+			LuceneMaxBufferSizeMB     *int64   `json:"luceneMaxBufferSizeMB"`
+			LuceneAnalyzerClass       *string  `json:"luceneAnalyzerClass"`
+		} `json:"text"`
+		FST *struct {
+			Type *string `json:"type"`
+		} `json:"fst"`
+		H3 *struct {
+			Resolutions []int64 `json:"resolution"`
+		} `json:"h3"`
+		Range *struct {
+			Version *int64 `json:"version"`
+		} `json:"range"`
+		Bloom *struct {
+			FPP            *float64 `json:"fpp"`
+			MaxSizeInBytes *int64   `json:"maxSizeInBytes"`
+			LoadOnHeap     *bool    `json:"loadOnHeap"`
+		} `json:"bloom"`
+		Forward *struct {
+			Disabled              *bool   `json:"disabled"`
+			CompressionCodec      *string `json:"compressionCodec"`
+			DeriveNumDocsPerChunk *bool   `json:"deriveNumDocsPerChunk"`
+			RawIndexWriterVersion *int64  `json:"rawIndexWriterVersion"`
+		} `json:"forward"`
+		Vector *struct {
+			VectorIndexType        *string           `json:"vectorIndexType"`
+			VectorDimension        *int64            `json:"vectorDimension"`
+			VectorDistanceFunction *string           `json:"vectorDistanceFunction"`
+			Version                *int64            `json:"version"`
+			Properties             map[string]string `json:"properties"`
+		} `json:"vector"`
+	} `json:"indexes"`
+}
+
+func convertFieldConfigList(ctx context.Context, value any) ([]*models.FieldConfig, error) {
+
+	var fieldConfigs []fieldConfig
+	err := decode(value, &fieldConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldConfigList []*models.FieldConfig
+	for _, fc := range fieldConfigs {
+
+		fieldConfig := models.FieldConfig{
+			Name:             types.StringValue(fc.Name),
+			EncodingType:     types.StringValue(fc.EncodingType),
+			IndexType:        types.StringValue(fc.IndexType),
+			CompressionCodec: types.StringPointerValue(fc.CompressionCodec),
+			Properties:       stringMapValue(ctx, fc.Properties),
+		}
+
+		if len(fc.IndexTypes) > 0 {
+			fieldConfig.IndexTypes = fc.IndexTypes
+		}
+
+		if fc.TimestampConfig != nil {
+			fieldConfig.TimestampConfig = &models.TimestampConfig{
+				Granularities: fc.TimestampConfig.Granularities,
+			}
+		}
+
+		if fc.Indexes != nil {
+			fieldConfig.Indexes = &models.FieldIndexes{}
+			indexes := fc.Indexes
+
+			if indexes.Inverted != nil {
+				fieldConfig.Indexes.Inverted = &models.FiendIndexInverted{
+					Enabled: types.StringValue(scalarString(indexes.Inverted.Enabled)),
+				}
+			}
+
+			if indexes.JSON != nil {
+				fieldConfig.Indexes.JSON = &models.FieldIndexJSON{
+					MaxLevels:               types.Int64PointerValue(indexes.JSON.MaxLevels),
+					ExcludeArray:            types.BoolPointerValue(indexes.JSON.ExcludeArray),
+					DisableCrossArrayUnnest: types.BoolPointerValue(indexes.JSON.DisableCrossArrayUnnest),
+					IncludePaths:            stringListValue(ctx, indexes.JSON.IncludePaths),
+					ExcludePaths:            stringListValue(ctx, indexes.JSON.ExcludePaths),
+					ExcludeFields:           stringListValue(ctx, indexes.JSON.ExcludeFields),
+					IndexPaths:              stringListValue(ctx, indexes.JSON.IndexPaths),
+					MaxValueLength:          types.Int64PointerValue(indexes.JSON.MaxValueLength),
+				}
+			}
+
+			if indexes.Text != nil {
+				fieldConfig.Indexes.Text = &models.FieldIndexText{
+					FSTType:                   types.StringPointerValue(indexes.Text.FSTType),
+					RawValue:                  types.StringPointerValue(indexes.Text.RawValue),
+					QueryCache:                types.BoolPointerValue(indexes.Text.QueryCache),
+					UseANDForMultiTermQueries: types.BoolPointerValue(indexes.Text.UseANDForMultiTermQueries),
+					StopWordsInclude:          stringListValue(ctx, indexes.Text.StopWordsInclude),
+					StopWordsExclude:          stringListValue(ctx, indexes.Text.StopWordsExclude),
+					LuceneUseCompoundFile:     types.BoolPointerValue(indexes.Text.LuceneUseCompoundFile),
+					LuceneMaxBufferSizeMB:     types.Int64PointerValue(indexes.Text.LuceneMaxBufferSizeMB),
+					LuceneAnalyzerClass:       types.StringPointerValue(indexes.Text.LuceneAnalyzerClass),
+				}
+			}
+
+			if indexes.FST != nil {
+				fieldConfig.Indexes.FST = &models.FieldIndexFST{
+					Type: types.StringPointerValue(indexes.FST.Type),
+				}
+			}
+
+			if indexes.H3 != nil {
+				resolutions := types.ListNull(types.Int64Type)
+				if len(indexes.H3.Resolutions) > 0 {
+					resolutions, _ = types.ListValueFrom(ctx, types.Int64Type, indexes.H3.Resolutions)
+				}
+				fieldConfig.Indexes.H3 = &models.FieldIndexH3{
+					Resolutions: resolutions,
+				}
+			}
+
+			if indexes.Range != nil {
+				fieldConfig.Indexes.Range = &models.FieldIndexRange{
+					Version: types.Int64PointerValue(indexes.Range.Version),
+				}
+			}
+
+			if indexes.Bloom != nil {
+				fieldConfig.Indexes.Bloom = &models.FieldIndexBloom{
+					FPP:            types.Float64PointerValue(indexes.Bloom.FPP),
+					MaxSizeInBytes: types.Int64PointerValue(indexes.Bloom.MaxSizeInBytes),
+					LoadOnHeap:     types.BoolPointerValue(indexes.Bloom.LoadOnHeap),
+				}
+			}
+
+			if indexes.Forward != nil {
+				fieldConfig.Indexes.Forward = &models.FieldIndexForward{
+					Disabled:              types.BoolPointerValue(indexes.Forward.Disabled),
+					CompressionCodec:      types.StringPointerValue(indexes.Forward.CompressionCodec),
+					DeriveNumDocsPerChunk: types.BoolPointerValue(indexes.Forward.DeriveNumDocsPerChunk),
+					RawIndexWriterVersion: types.Int64PointerValue(indexes.Forward.RawIndexWriterVersion),
+				}
+			}
+
+			if indexes.Vector != nil {
+				fieldConfig.Indexes.Vector = &models.FieldIndexVector{
+					VectorIndexType:        types.StringPointerValue(indexes.Vector.VectorIndexType),
+					VectorDimension:        types.Int64PointerValue(indexes.Vector.VectorDimension),
+					VectorDistanceFunction: types.StringPointerValue(indexes.Vector.VectorDistanceFunction),
+					Version:                types.Int64PointerValue(indexes.Vector.Version),
+					Properties:             stringMapValue(ctx, indexes.Vector.Properties),
+				}
+			}
+		}
+
+		fieldConfigList = append(fieldConfigList, &fieldConfig)
+	}
+
+	return fieldConfigList, nil
+}
+
+// stringListValue converts values to a list, null when empty so unset attributes do not show a diff.
+func stringListValue(ctx context.Context, values []string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	list, _ := types.ListValueFrom(ctx, types.StringType, values)
+	return list
+}
+
+// stringMapValue converts values to a map, null when empty so unset attributes do not show a diff.
+func stringMapValue(ctx context.Context, values map[string]string) types.Map {
+	if len(values) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	m, _ := types.MapValueFrom(ctx, types.StringType, values)
+	return m
+}
+
+// scalarString renders a JSON scalar the way it is written in config, the controller accepts both "true" and true.
+func scalarString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// lookup returns the value at the given keys of a decoded table config, or nil when any of them is missing.
+func lookup(tableConfig map[string]any, keys ...string) any {
+
+	var value any = tableConfig
+	for _, key := range keys {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+
+	return value
+}
+
+// decode converts a decoded JSON value into target by round tripping it through JSON.
+func decode(value any, target any) error {
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(valueBytes, target)
+}
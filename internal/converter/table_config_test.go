@@ -0,0 +1,241 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"terraform-provider-pinot/internal/models"
+)
+
+func TestSetStateFromTableConfigSegmentPartitionConfig(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_REALTIME",
+		"tableType": "REALTIME",
+		"tableIndexConfig": {
+			"segmentPartitionConfig": {
+				"columnPartitionMap": {
+					"user_id": {"functionName": "Murmur3", "numPartitions": 8, "functionConfig": {"seed": "9001"}}
+				}
+			}
+		}
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state models.TableResourceModel
+	err = SetStateFromTableConfig(context.Background(), &state, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	partitionConfig := state.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap["user_id"]
+
+	if partitionConfig.FunctionName.ValueString() != "Murmur3" {
+		t.Errorf("expected function name Murmur3, got %s", partitionConfig.FunctionName)
+	}
+
+	if partitionConfig.NumPartitions.ValueInt64() != 8 {
+		t.Errorf("expected 8 partitions, got %s", partitionConfig.NumPartitions)
+	}
+
+	if partitionConfig.FunctionConfig.String() != `{"seed":"9001"}` {
+		t.Errorf("expected function config with seed, got %s", partitionConfig.FunctionConfig)
+	}
+}
+
+func TestSetStateFromTableConfigFieldConfigList(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_OFFLINE",
+		"tableType": "OFFLINE",
+		"fieldConfigList": [
+			{
+				"name": "payload",
+				"encodingType": "RAW",
+				"indexType": "JSON",
+				"compressionCodec": "ZSTANDARD",
+				"indexes": {
+					"inverted": {"enabled": true},
+					"json": {"maxLevels": 2, "excludeArray": true},
+					"h3": {"resolution": [5, 6]},
+					"forward": {"disabled": true}
+				}
+			}
+		]
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state models.TableResourceModel
+	err = SetStateFromTableConfig(context.Background(), &state, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(state.FieldConfigList) != 1 {
+		t.Fatalf("expected 1 field config, got %d", len(state.FieldConfigList))
+	}
+
+	fieldConfig := state.FieldConfigList[0]
+
+	if fieldConfig.CompressionCodec.ValueString() != "ZSTANDARD" {
+		t.Errorf("expected compression codec ZSTANDARD, got %s", fieldConfig.CompressionCodec)
+	}
+
+	if !fieldConfig.Properties.IsNull() {
+		t.Errorf("expected properties to be null, got %s", fieldConfig.Properties)
+	}
+
+	if fieldConfig.Indexes.Inverted.Enabled.ValueString() != "true" {
+		t.Errorf("expected inverted index to be enabled, got %s", fieldConfig.Indexes.Inverted.Enabled)
+	}
+
+	if fieldConfig.Indexes.JSON.MaxLevels.ValueInt64() != 2 || !fieldConfig.Indexes.JSON.MaxValueLength.IsNull() {
+		t.Errorf("unexpected json index %+v", fieldConfig.Indexes.JSON)
+	}
+
+	if fieldConfig.Indexes.H3.Resolutions.String() != "[5,6]" {
+		t.Errorf("expected h3 resolutions [5,6], got %s", fieldConfig.Indexes.H3.Resolutions)
+	}
+
+	if !fieldConfig.Indexes.Forward.Disabled.ValueBool() {
+		t.Error("expected forward index to be disabled")
+	}
+
+	if fieldConfig.Indexes.Text != nil || fieldConfig.Indexes.Vector != nil {
+		t.Error("expected unset indexes to be nil")
+	}
+}
+
+func TestSetStateFromTableConfigRoutingQueryQuota(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_OFFLINE",
+		"tableType": "OFFLINE",
+		"routing": {"segmentPrunerTypes": ["partition"], "instanceSelectorType": "replicaGroup"},
+		"query": {"timeoutMs": 15000},
+		"quota": {"storage": "10G", "maxQueriesPerSecond": "300.0"}
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state models.TableResourceModel
+	err = SetStateFromTableConfig(context.Background(), &state, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if state.Routing.InstanceSelectorType.ValueString() != "replicaGroup" || state.Routing.SegmentPrunerTypes.String() != `["partition"]` {
+		t.Errorf("unexpected routing %+v", state.Routing)
+	}
+
+	if state.Query.TimeoutMs.ValueInt64() != 15000 || !state.Query.ExpressionOverrideMap.IsNull() {
+		t.Errorf("unexpected query %+v", state.Query)
+	}
+
+	if state.Quota.Storage.ValueString() != "10G" || state.Quota.MaxQueriesPerSecond.ValueFloat64() != 300 {
+		t.Errorf("unexpected quota %+v", state.Quota)
+	}
+}
+
+func TestSetStateFromTableConfigIngestionConfig(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_OFFLINE",
+		"tableType": "OFFLINE",
+		"ingestionConfig": {
+			"batchIngestionConfig": {
+				"segmentIngestionType": "APPEND",
+				"segmentIngestionFrequency": "DAILY",
+				"batchConfigMaps": [{"inputDirURI": "s3://events/", "inputFormat": "parquet"}]
+			},
+			"filterConfig": {"filterFunction": "Groovy({country == 'XX'}, country)"},
+			"complexTypeConfig": {"fieldsToUnnest": ["items"], "delimiter": "."}
+		}
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state models.TableResourceModel
+	err = SetStateFromTableConfig(context.Background(), &state, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ingestionConfig := state.IngestionConfig
+
+	if ingestionConfig.BatchIngestionConfig.SegmentIngestionType.ValueString() != "APPEND" || ingestionConfig.BatchIngestionConfig.BatchConfigMaps[0]["inputFormat"] != "parquet" {
+		t.Errorf("unexpected batch ingestion config %+v", ingestionConfig.BatchIngestionConfig)
+	}
+
+	if ingestionConfig.FilterConfig.FilterFunction.ValueString() != "Groovy({country == 'XX'}, country)" {
+		t.Errorf("unexpected filter config %+v", ingestionConfig.FilterConfig)
+	}
+
+	if ingestionConfig.ComplexTypeConfig.FieldsToUnnest.String() != `["items"]` || !ingestionConfig.ComplexTypeConfig.CollectionNotUnnestedToJSON.IsNull() {
+		t.Errorf("unexpected complex type config %+v", ingestionConfig.ComplexTypeConfig)
+	}
+
+	if ingestionConfig.AggregationConfigs != nil {
+		t.Errorf("expected no aggregation configs, got %d", len(ingestionConfig.AggregationConfigs))
+	}
+}
+
+func TestSetStateFromTableConfigTierConfigs(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_OFFLINE",
+		"tableType": "OFFLINE",
+		"tableIndexConfig": {
+			"tierOverwrites": {
+				"coldTier": {
+					"noDictionaryColumns": ["country"],
+					"starTreeIndexConfigs": [{"dimensionsSplitOrder": ["country"], "functionColumnPairs": ["COUNT__*"], "maxLeafRecords": 10000}]
+				}
+			}
+		},
+		"tierConfigs": [
+			{"name": "coldTier", "segmentSelectorType": "fixed", "segmentList": ["events_0", "events_1"], "storageType": "pinot_server", "serverTag": "cold_OFFLINE"}
+		]
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var state models.TableResourceModel
+	err = SetStateFromTableConfig(context.Background(), &state, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tierConfig := state.TierConfigs[0]
+
+	if tierConfig.SegmentList.String() != `["events_0","events_1"]` {
+		t.Errorf("expected the segment list, got %s", tierConfig.SegmentList)
+	}
+
+	if !tierConfig.SegmentAge.IsNull() {
+		t.Errorf("expected no segment age, got %s", tierConfig.SegmentAge)
+	}
+
+	tierOverwrite := state.TableIndexConfig.TierOverwrites["coldTier"]
+
+	if tierOverwrite.NoDictionaryColumns.String() != `["country"]` {
+		t.Errorf("expected the no dictionary columns, got %s", tierOverwrite.NoDictionaryColumns)
+	}
+
+	if len(tierOverwrite.StarTreeIndexConfigs) != 1 || tierOverwrite.StarTreeIndexConfigs[0].MaxLeafRecords.ValueInt64() != 10000 {
+		t.Errorf("expected one star tree index config, got %v", tierOverwrite.StarTreeIndexConfigs)
+	}
+}
@@ -0,0 +1,251 @@
+package customtypes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = TableConfigType{}
+	_ basetypes.StringValuableWithSemanticEquals = TableConfigValue{}
+)
+
+// tableConfigDefaults are the non-empty values the controller fills in when a table config leaves them out.
+// Every other path defaults to an empty value (null, false, 0, "", [] and {}), the controller returns them for every
+// primitive field of its config classes, so they are not listed here.
+var tableConfigDefaults = map[string]string{
+	"tenants.broker":                                    "DefaultTenant",
+	"tenants.server":                                    "DefaultTenant",
+	"segmentsConfig.replication":                        "1",
+	"tableIndexConfig.loadMode":                         "MMAP",
+	"tableIndexConfig.rangeIndexVersion":                "2",
+	"tableIndexConfig.noDictionarySizeRatioThreshold":   "0.85",
+	"tableIndexConfig.columnMajorSegmentBuilderEnabled": "true",
+	"fieldConfigList[].encodingType":                    "DICTIONARY",
+	"ingestionConfig.segmentTimeValueCheck":             "true",
+	"upsertConfig.hashFunction":                         "NONE",
+	"upsertConfig.defaultPartialUpsertStrategy":         "OVERWRITE",
+	"upsertConfig.consistencyMode":                      "NONE",
+	"upsertConfig.upsertViewRefreshIntervalMs":          "3000",
+	"upsertConfig.newSegmentTrackingTimeMs":             "10000",
+	"upsertConfig.snapshot":                             "DEFAULT",
+	"upsertConfig.preload":                              "DEFAULT",
+	"dedupConfig.hashFunction":                          "NONE",
+}
+
+// TableConfigType is a string type holding a Pinot table config as JSON.
+type TableConfigType struct {
+	basetypes.StringType
+}
+
+func (t TableConfigType) Equal(o attr.Type) bool {
+	other, ok := o.(TableConfigType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t TableConfigType) String() string {
+	return "customtypes.TableConfigType"
+}
+
+func (t TableConfigType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return TableConfigValue{StringValue: in}, nil
+}
+
+func (t TableConfigType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t TableConfigType) ValueType(_ context.Context) attr.Value {
+	return TableConfigValue{}
+}
+
+// TableConfigValue is a Pinot table config as JSON. Two values are semantically equal when they describe the same
+// table config, regardless of formatting, key order and the defaults the controller adds.
+type TableConfigValue struct {
+	basetypes.StringValue
+}
+
+func NewTableConfigValue(value string) TableConfigValue {
+	return TableConfigValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func NewTableConfigNull() TableConfigValue {
+	return TableConfigValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewTableConfigUnknown() TableConfigValue {
+	return TableConfigValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v TableConfigValue) Equal(o attr.Value) bool {
+	other, ok := o.(TableConfigValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v TableConfigValue) Type(_ context.Context) attr.Type {
+	return TableConfigType{}
+}
+
+func (v TableConfigValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TableConfigValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	equal, err := TableConfigsEqual(v.ValueString(), newValue.ValueString())
+	if err != nil {
+		// invalid JSON is reported by validation, treat it as a regular change here
+		return false, diags
+	}
+
+	return equal, diags
+}
+
+// TableConfigsEqual reports whether two table config JSON documents describe the same table config.
+func TableConfigsEqual(a string, b string) (bool, error) {
+
+	normalizedA, err := NormalizeTableConfig(a)
+	if err != nil {
+		return false, err
+	}
+
+	normalizedB, err := NormalizeTableConfig(b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(normalizedA, normalizedB), nil
+}
+
+// NormalizeTableConfig decodes a table config and drops everything that does not change its meaning:
+// the type suffix the controller adds to the table name, empty values and controller defaults.
+// Scalars are compared as strings because the controller returns some numbers as strings, e.g. replication.
+func NormalizeTableConfig(tableConfig string) (map[string]any, error) {
+
+	var decoded map[string]any
+	err := json.Unmarshal([]byte(tableConfig), &decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	if tableName, ok := decoded["tableName"].(string); ok {
+		decoded["tableName"] = TrimTableTypeSuffix(tableName)
+	}
+
+	if tableType, ok := decoded["tableType"].(string); ok {
+		decoded["tableType"] = strings.ToUpper(tableType)
+	}
+
+	normalized, _ := normalize("", decoded).(map[string]any)
+	if normalized == nil {
+		normalized = map[string]any{}
+	}
+
+	return normalized, nil
+}
+
+// TrimTableTypeSuffix strips the _OFFLINE or _REALTIME suffix the controller appends to table names.
+func TrimTableTypeSuffix(tableName string) string {
+	for _, suffix := range []string{"_OFFLINE", "_REALTIME"} {
+		tableName = strings.TrimSuffix(tableName, suffix)
+	}
+	return tableName
+}
+
+// normalize returns nil for values that are empty or equal to the controller default at path.
+func normalize(path string, value any) any {
+
+	switch v := value.(type) {
+	case map[string]any:
+		normalized := map[string]any{}
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if normalizedChild := normalize(childPath, child); normalizedChild != nil {
+				normalized[key] = normalizedChild
+			}
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return normalized
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		normalized := make([]any, len(v))
+		for i, child := range v {
+			normalized[i] = normalize(path+"[]", child)
+		}
+		return normalized
+	case nil:
+		return nil
+	}
+
+	// an empty value is only the default where the controller has no other one, an explicit false must not
+	// compare equal to a missing key that defaults to true
+	scalar := scalarString(value)
+	defaultValue, ok := tableConfigDefaults[path]
+	if scalar == defaultValue || !ok && (scalar == "false" || scalar == "0") {
+		return nil
+	}
+
+	return scalar
+}
+
+func scalarString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,94 @@
+package customtypes
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTableConfigsEqual(t *testing.T) {
+
+	testCases := map[string]struct {
+		a     string
+		b     string
+		equal bool
+	}{
+		"formatting and key order": {
+			a:     `{"tableName":"events","tableType":"OFFLINE","segmentsConfig":{"replication":"3","timeColumnName":"ts"}}`,
+			b:     "{\n  \"tableType\": \"OFFLINE\",\n  \"segmentsConfig\": {\"timeColumnName\": \"ts\", \"replication\": \"3\"},\n  \"tableName\": \"events\"\n}",
+			equal: true,
+		},
+		"controller table name suffix and defaults": {
+			a:     `{"tableName":"events","tableType":"OFFLINE","segmentsConfig":{"replication":"3"}}`,
+			b:     `{"tableName":"events_OFFLINE","tableType":"OFFLINE","segmentsConfig":{"replication":"3","minimizeDataMovement":false},"tenants":{"broker":"DefaultTenant","server":"DefaultTenant"},"metadata":{},"tableIndexConfig":{"rangeIndexVersion":2,"invertedIndexColumns":[]}}`,
+			equal: true,
+		},
+		"primitive and nested defaults": {
+			a:     `{"tableName":"events","upsertConfig":{"mode":"FULL"},"fieldConfigList":[{"name":"payload","indexTypes":["TEXT"]}]}`,
+			b:     `{"tableName":"events","upsertConfig":{"mode":"FULL","metadataTTL":0,"deletedKeysTTL":0,"enableSnapshot":false,"snapshot":"DEFAULT","newSegmentTrackingTimeMs":10000},"fieldConfigList":[{"name":"payload","encodingType":"DICTIONARY","indexTypes":["TEXT"]}]}`,
+			equal: true,
+		},
+		"numbers returned as strings": {
+			a:     `{"tableName":"events","quota":{"maxQueriesPerSecond":100}}`,
+			b:     `{"tableName":"events","quota":{"maxQueriesPerSecond":"100"}}`,
+			equal: true,
+		},
+		"changed value": {
+			a:     `{"tableName":"events","segmentsConfig":{"replication":"3"}}`,
+			b:     `{"tableName":"events","segmentsConfig":{"replication":"2"}}`,
+			equal: false,
+		},
+		"non-default value differs from missing": {
+			a:     `{"tableName":"events"}`,
+			b:     `{"tableName":"events","tenants":{"server":"analytics"}}`,
+			equal: false,
+		},
+		"false differs from a default of true": {
+			a:     `{"tableName":"events","ingestionConfig":{"segmentTimeValueCheck":false}}`,
+			b:     `{"tableName":"events","ingestionConfig":{"segmentTimeValueCheck":true}}`,
+			equal: false,
+		},
+		"false differs from a missing key that defaults to true": {
+			a:     `{"tableName":"events","tableIndexConfig":{"columnMajorSegmentBuilderEnabled":false}}`,
+			b:     `{"tableName":"events"}`,
+			equal: false,
+		},
+		"list order matters": {
+			a:     `{"tableName":"events","tableIndexConfig":{"sortedColumn":["a","b"]}}`,
+			b:     `{"tableName":"events","tableIndexConfig":{"sortedColumn":["b","a"]}}`,
+			equal: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, err := TableConfigsEqual(testCase.a, testCase.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if equal != testCase.equal {
+				t.Errorf("expected equal to be %t, got %t", testCase.equal, equal)
+			}
+		})
+	}
+}
+
+func TestTableConfigValueStringSemanticEquals(t *testing.T) {
+
+	prior := NewTableConfigValue(`{"tableName":"events","tableType":"REALTIME"}`)
+
+	equal, diags := prior.StringSemanticEquals(context.Background(), NewTableConfigValue("{\n  \"tableType\": \"realtime\",\n  \"tableName\": \"events\"\n}"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Error("expected reformatted table config to be semantically equal")
+	}
+
+	equal, diags = prior.StringSemanticEquals(context.Background(), NewTableConfigValue("not json"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if equal {
+		t.Error("expected invalid JSON not to be semantically equal")
+	}
+}
@@ -1,26 +1,45 @@
 package models
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"terraform-provider-pinot/internal/customtypes"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type TableResourceModel struct {
-	TableName        types.String      `tfsdk:"table_name"`
-	Table            types.String      `tfsdk:"table"`
-	TableType        types.String      `tfsdk:"table_type"`
-	SegmentsConfig   *SegmentsConfig   `tfsdk:"segments_config"`
-	TenantsConfig    *TenantsConfig    `tfsdk:"tenants"`
-	TableIndexConfig *TableIndexConfig `tfsdk:"table_index_config"`
-	UpsertConfig     *UpsertConfig     `tfsdk:"upsert_config"`
-	IngestionConfig  *IngestionConfig  `tfsdk:"ingestion_config"`
-	TierConfigs      []*TierConfig     `tfsdk:"tier_configs"`
-	IsDimTable       types.Bool        `tfsdk:"is_dim_table"`
-	Metadata         *Metadata         `tfsdk:"metadata"`
-	FieldConfigList  []*FieldConfig    `tfsdk:"field_config_list"`
+	TableName                   types.String                        `tfsdk:"table_name"`
+	Table                       customtypes.TableConfigValue        `tfsdk:"table"`
+	TableType                   types.String                        `tfsdk:"table_type"`
+	SegmentsConfig              *SegmentsConfig                     `tfsdk:"segments_config"`
+	TenantsConfig               *TenantsConfig                      `tfsdk:"tenants"`
+	TableIndexConfig            *TableIndexConfig                   `tfsdk:"table_index_config"`
+	UpsertConfig                *UpsertConfig                       `tfsdk:"upsert_config"`
+	IngestionConfig             *IngestionConfig                    `tfsdk:"ingestion_config"`
+	TierConfigs                 []*TierConfig                       `tfsdk:"tier_configs"`
+	TaskConfig                  types.Map                           `tfsdk:"task_config"`
+	IsDimTable                  types.Bool                          `tfsdk:"is_dim_table"`
+	DimensionTableConfig        *DimensionTableConfig               `tfsdk:"dimension_table_config"`
+	DedupConfig                 *DedupConfig                        `tfsdk:"dedup_config"`
+	Metadata                    *Metadata                           `tfsdk:"metadata"`
+	InstanceAssignmentConfigMap map[string]InstanceAssignmentConfig `tfsdk:"instance_assignment_config_map"`
+	SegmentAssignmentConfigMap  map[string]SegmentAssignmentConfig  `tfsdk:"segment_assignment_config_map"`
+	InstancePartitionsMap       types.Map                           `tfsdk:"instance_partitions_map"`
+	Routing                     *RoutingConfig                      `tfsdk:"routing"`
+	Query                       *QueryConfig                        `tfsdk:"query"`
+	Quota                       *QuotaConfig                        `tfsdk:"quota"`
+	FieldConfigList             []*FieldConfig                      `tfsdk:"field_config_list"`
+	StreamSecrets               types.Map                           `tfsdk:"stream_secrets"`
+	DetectStreamSecretDrift     types.Bool                          `tfsdk:"detect_stream_secret_drift"`
+	EffectiveConfigJSON         types.String                        `tfsdk:"effective_config_json"`
+	DeleteSchema                types.Bool                          `tfsdk:"delete_schema"`
+	Timeouts                    timeouts.Value                      `tfsdk:"timeouts"`
 }
 
 type TenantsConfig struct {
-	Broker            types.String   `tfsdk:"broker"`
-	Server            types.String   `tfsdk:"server"`
-	TagOverrideConfig *types.MapType `tfsdk:"tag_override_config"`
+	Broker            types.String `tfsdk:"broker"`
+	Server            types.String `tfsdk:"server"`
+	TagOverrideConfig types.Map    `tfsdk:"tag_override_config"`
 }
 
 type SegmentsConfig struct {
@@ -34,12 +53,18 @@ type SegmentsConfig struct {
 }
 
 type UpsertConfig struct {
-	Mode                  types.String  `tfsdk:"mode"`
-	PartialUpsertStrategy types.MapType `tfsdk:"partial_upsert_strategy"`
+	Mode                    types.String `tfsdk:"mode"`
+	PartialUpsertStrategies types.Map    `tfsdk:"partial_upsert_strategies"`
 }
 
 type SegmentPartitionConfig struct {
-	ColumnPartitionMap map[string]map[string]string `tfsdk:"column_partition_map"`
+	ColumnPartitionMap map[string]ColumnPartitionConfig `tfsdk:"column_partition_map"`
+}
+
+type ColumnPartitionConfig struct {
+	FunctionName   types.String `tfsdk:"function_name"`
+	NumPartitions  types.Int64  `tfsdk:"num_partitions"`
+	FunctionConfig types.Map    `tfsdk:"function_config"`
 }
 
 type TimestampConfig struct {
@@ -50,40 +75,112 @@ type FiendIndexInverted struct {
 	Enabled types.String `tfsdk:"enabled"`
 }
 
+type FieldIndexJSON struct {
+	MaxLevels               types.Int64 `tfsdk:"max_levels"`
+	ExcludeArray            types.Bool  `tfsdk:"exclude_array"`
+	DisableCrossArrayUnnest types.Bool  `tfsdk:"disable_cross_array_unnest"`
+	IncludePaths            types.List  `tfsdk:"include_paths"`
+	ExcludePaths            types.List  `tfsdk:"exclude_paths"`
+	ExcludeFields           types.List  `tfsdk:"exclude_fields"`
+	IndexPaths              types.List  `tfsdk:"index_paths"`
+	MaxValueLength          types.Int64 `tfsdk:"max_value_length"`
+}
+
+type FieldIndexText struct {
+	FSTType                   types.String `tfsdk:"fst_type"`
+	RawValue                  types.String `tfsdk:"raw_value"`
+	QueryCache                types.Bool   `tfsdk:"query_cache"`
+	UseANDForMultiTermQueries types.Bool   `tfsdk:"use_and_for_multi_term_queries"`
+	StopWordsInclude          types.List   `tfsdk:"stop_words_include"`
+	StopWordsExclude          types.List   `tfsdk:"stop_words_exclude"`
+	LuceneUseCompoundFile     types.Bool   `tfsdk:"lucene_use_compound_file"`
+	LuceneMaxBufferSizeMB     types.Int64  `tfsdk:"lucene_max_buffer_size_mb"`
+	LuceneAnalyzerClass       types.String `tfsdk:"lucene_analyzer_class"`
+}
+
+type FieldIndexFST struct {
+	Type types.String `tfsdk:"type"`
+}
+
+type FieldIndexH3 struct {
+	Resolutions types.List `tfsdk:"resolutions"`
+}
+
+type FieldIndexRange struct {
+	Version types.Int64 `tfsdk:"version"`
+}
+
+type FieldIndexBloom struct {
+	FPP            types.Float64 `tfsdk:"fpp"`
+	MaxSizeInBytes types.Int64   `tfsdk:"max_size_in_bytes"`
+	LoadOnHeap     types.Bool    `tfsdk:"load_on_heap"`
+}
+
+type FieldIndexForward struct {
+	Disabled              types.Bool   `tfsdk:"disabled"`
+	CompressionCodec      types.String `tfsdk:"compression_codec"`
+	DeriveNumDocsPerChunk types.Bool   `tfsdk:"derive_num_docs_per_chunk"`
+	RawIndexWriterVersion types.Int64  `tfsdk:"raw_index_writer_version"`
+}
+
+type FieldIndexVector struct {
+	VectorIndexType        types.String `tfsdk:"vector_index_type"`
+	VectorDimension        types.Int64  `tfsdk:"vector_dimension"`
+	VectorDistanceFunction types.String `tfsdk:"vector_distance_function"`
+	Version                types.Int64  `tfsdk:"version"`
+	Properties             types.Map    `tfsdk:"properties"`
+}
+
 type FieldIndexes struct {
 	Inverted *FiendIndexInverted `tfsdk:"inverted"`
+	JSON     *FieldIndexJSON     `tfsdk:"json"`
+	Text     *FieldIndexText     `tfsdk:"text"`
+	FST      *FieldIndexFST      `tfsdk:"fst"`
+	H3       *FieldIndexH3       `tfsdk:"h3"`
+	Range    *FieldIndexRange    `tfsdk:"range"`
+	Bloom    *FieldIndexBloom    `tfsdk:"bloom"`
+	Forward  *FieldIndexForward  `tfsdk:"forward"`
+	Vector   *FieldIndexVector   `tfsdk:"vector"`
 }
 
 type FieldConfig struct {
-	Name            types.String     `tfsdk:"name"`
-	EncodingType    types.String     `tfsdk:"encoding_type"`
-	IndexType       types.String     `tfsdk:"index_type"`
-	IndexTypes      []string         `tfsdk:"index_types"`
-	TimestampConfig *TimestampConfig `tfsdk:"timestamp_config"`
-	Indexes         *FieldIndexes    `tfsdk:"indexes"`
+	Name             types.String     `tfsdk:"name"`
+	EncodingType     types.String     `tfsdk:"encoding_type"`
+	IndexType        types.String     `tfsdk:"index_type"`
+	IndexTypes       []string         `tfsdk:"index_types"`
+	CompressionCodec types.String     `tfsdk:"compression_codec"`
+	Properties       types.Map        `tfsdk:"properties"`
+	TimestampConfig  *TimestampConfig `tfsdk:"timestamp_config"`
+	Indexes          *FieldIndexes    `tfsdk:"indexes"`
 }
 
 type TableIndexConfig struct {
-	SortedColumn                               types.List              `tfsdk:"sorted_column"`
-	LoadMode                                   types.String            `tfsdk:"load_mode"`
-	NullHandlingEnabled                        types.Bool              `tfsdk:"null_handling_enabled"`
-	CreateInvertedIndexDuringSegmentGeneration types.Bool              `tfsdk:"create_inverted_index_during_segment_generation"`
-	EnableDynamicStarTree                      types.Bool              `tfsdk:"enable_dynamic_star_tree"`
-	EnableDefaultStarTree                      types.Bool              `tfsdk:"enable_default_star_tree"`
-	OptimizeDictionary                         types.Bool              `tfsdk:"optimize_dictionary"`
-	OptimizeDictionaryForMetrics               types.Bool              `tfsdk:"optimize_dictionary_for_metrics"`
-	NoDictionarySizeRatioThreshold             types.Float64           `tfsdk:"no_dictionary_size_ratio_threshold"`
-	ColumnMinMaxValueGeneratorMode             types.String            `tfsdk:"column_min_max_value_generator_mode"`
-	SegmentNameGeneratorType                   types.String            `tfsdk:"segment_name_generator_type"`
-	AggregateMetrics                           types.Bool              `tfsdk:"aggregate_metrics"`
-	StarTreeIndexConfigs                       []*StarTreeIndexConfigs `tfsdk:"star_tree_index_configs"`
-	SegmentPartitionConfig                     *SegmentPartitionConfig `tfsdk:"segment_partition_config"`
-	NoDictionaryColumns                        types.List              `tfsdk:"no_dictionary_columns"`
-	RangeIndexColumns                          types.List              `tfsdk:"range_index_columns"`
-	OnHeapDictionaryColumns                    types.List              `tfsdk:"on_heap_dictionary_columns"`
-	VarLengthDictionaryColumns                 types.List              `tfsdk:"var_length_dictionary_columns"`
-	BloomFilterColumns                         types.List              `tfsdk:"bloom_filter_columns"`
-	RangeIndexVersion                          types.Int64             `tfsdk:"range_index_version"`
+	SortedColumn                               types.List               `tfsdk:"sorted_column"`
+	LoadMode                                   types.String             `tfsdk:"load_mode"`
+	NullHandlingEnabled                        types.Bool               `tfsdk:"null_handling_enabled"`
+	CreateInvertedIndexDuringSegmentGeneration types.Bool               `tfsdk:"create_inverted_index_during_segment_generation"`
+	EnableDynamicStarTree                      types.Bool               `tfsdk:"enable_dynamic_star_tree"`
+	EnableDefaultStarTree                      types.Bool               `tfsdk:"enable_default_star_tree"`
+	OptimizeDictionary                         types.Bool               `tfsdk:"optimize_dictionary"`
+	OptimizeDictionaryForMetrics               types.Bool               `tfsdk:"optimize_dictionary_for_metrics"`
+	NoDictionarySizeRatioThreshold             types.Float64            `tfsdk:"no_dictionary_size_ratio_threshold"`
+	ColumnMinMaxValueGeneratorMode             types.String             `tfsdk:"column_min_max_value_generator_mode"`
+	SegmentNameGeneratorType                   types.String             `tfsdk:"segment_name_generator_type"`
+	AggregateMetrics                           types.Bool               `tfsdk:"aggregate_metrics"`
+	StarTreeIndexConfigs                       []*StarTreeIndexConfigs  `tfsdk:"star_tree_index_configs"`
+	SegmentPartitionConfig                     *SegmentPartitionConfig  `tfsdk:"segment_partition_config"`
+	NoDictionaryColumns                        types.List               `tfsdk:"no_dictionary_columns"`
+	RangeIndexColumns                          types.List               `tfsdk:"range_index_columns"`
+	OnHeapDictionaryColumns                    types.List               `tfsdk:"on_heap_dictionary_columns"`
+	VarLengthDictionaryColumns                 types.List               `tfsdk:"var_length_dictionary_columns"`
+	BloomFilterColumns                         types.List               `tfsdk:"bloom_filter_columns"`
+	RangeIndexVersion                          types.Int64              `tfsdk:"range_index_version"`
+	TierOverwrites                             map[string]TierOverwrite `tfsdk:"tier_overwrites"`
+}
+
+type TierOverwrite struct {
+	StarTreeIndexConfigs []*StarTreeIndexConfigs `tfsdk:"star_tree_index_configs"`
+	NoDictionaryColumns  types.List              `tfsdk:"no_dictionary_columns"`
 }
 
 type AggregationConfig struct {
@@ -106,15 +203,59 @@ type TransformConfig struct {
 }
 
 type IngestionConfig struct {
-	SegmentTimeValueCheck types.Bool             `tfsdk:"segment_time_value_check"`
-	RowTimeValueCheck     types.Bool             `tfsdk:"row_time_value_check"`
-	ContinueOnError       types.Bool             `tfsdk:"continue_on_error"`
-	StreamIngestionConfig *StreamIngestionConfig `tfsdk:"stream_ingestion_config"`
-	TransformConfigs      []*TransformConfig     `tfsdk:"transform_configs"`
+	SegmentTimeValueCheck types.Bool                    `tfsdk:"segment_time_value_check"`
+	RowTimeValueCheck     types.Bool                    `tfsdk:"row_time_value_check"`
+	ContinueOnError       types.Bool                    `tfsdk:"continue_on_error"`
+	StreamIngestionConfig *StreamIngestionConfig        `tfsdk:"stream_ingestion_config"`
+	TransformConfigs      []*TransformConfig            `tfsdk:"transform_configs"`
+	BatchIngestionConfig  *BatchIngestionConfig         `tfsdk:"batch_ingestion_config"`
+	FilterConfig          *FilterConfig                 `tfsdk:"filter_config"`
+	ComplexTypeConfig     *ComplexTypeConfig            `tfsdk:"complex_type_config"`
+	AggregationConfigs    []*IngestionAggregationConfig `tfsdk:"aggregation_configs"`
+}
+
+type BatchIngestionConfig struct {
+	SegmentIngestionType      types.String        `tfsdk:"segment_ingestion_type"`
+	SegmentIngestionFrequency types.String        `tfsdk:"segment_ingestion_frequency"`
+	ConsistentDataPush        types.Bool          `tfsdk:"consistent_data_push"`
+	BatchConfigMaps           []map[string]string `tfsdk:"batch_config_maps"`
+}
+
+type FilterConfig struct {
+	FilterFunction types.String `tfsdk:"filter_function"`
+}
+
+type ComplexTypeConfig struct {
+	FieldsToUnnest              types.List   `tfsdk:"fields_to_unnest"`
+	Delimiter                   types.String `tfsdk:"delimiter"`
+	CollectionNotUnnestedToJSON types.String `tfsdk:"collection_not_unnested_to_json"`
+	PrefixesToRename            types.Map    `tfsdk:"prefixes_to_rename"`
+}
+
+type IngestionAggregationConfig struct {
+	ColumnName          types.String `tfsdk:"column_name"`
+	AggregationFunction types.String `tfsdk:"aggregation_function"`
 }
 
 type StreamIngestionConfig struct {
 	StreamConfigMaps []map[string]string `tfsdk:"stream_config_maps"`
+	Kafka            *KafkaStreamConfig  `tfsdk:"kafka"`
+}
+
+type KafkaStreamConfig struct {
+	Topic                     types.String `tfsdk:"topic"`
+	BrokerList                types.String `tfsdk:"broker_list"`
+	ConsumerType              types.String `tfsdk:"consumer_type"`
+	ConsumerFactoryClass      types.String `tfsdk:"consumer_factory_class"`
+	DecoderClass              types.String `tfsdk:"decoder_class"`
+	DecoderProperties         types.Map    `tfsdk:"decoder_properties"`
+	OffsetCriteria            types.String `tfsdk:"offset_criteria"`
+	FlushThresholdRows        types.Int64  `tfsdk:"flush_threshold_rows"`
+	FlushThresholdTime        types.String `tfsdk:"flush_threshold_time"`
+	FlushThresholdSegmentSize types.String `tfsdk:"flush_threshold_segment_size"`
+	SecurityProtocol          types.String `tfsdk:"security_protocol"`
+	SaslMechanism             types.String `tfsdk:"sasl_mechanism"`
+	SecurityProperties        types.Map    `tfsdk:"security_properties"`
 }
 
 type TierConfig struct {
@@ -122,9 +263,72 @@ type TierConfig struct {
 	StorageType         types.String `tfsdk:"storage_type"`
 	SegmentSelectorType types.String `tfsdk:"segment_selector_type"`
 	SegmentAge          types.String `tfsdk:"segment_age"`
+	SegmentList         types.List   `tfsdk:"segment_list"`
 	ServerTag           types.String `tfsdk:"server_tag"`
 }
 
+type DimensionTableConfig struct {
+	DisablePreload             types.Bool `tfsdk:"disable_preload"`
+	ErrorOnDuplicatePrimaryKey types.Bool `tfsdk:"error_on_duplicate_primary_key"`
+}
+
+type DedupConfig struct {
+	Enabled         types.Bool    `tfsdk:"enabled"`
+	HashFunction    types.String  `tfsdk:"hash_function"`
+	MetadataTTL     types.Float64 `tfsdk:"metadata_ttl"`
+	DedupTimeColumn types.String  `tfsdk:"dedup_time_column"`
+}
+
+type TagPoolConfig struct {
+	Tag       types.String `tfsdk:"tag"`
+	PoolBased types.Bool   `tfsdk:"pool_based"`
+	NumPools  types.Int64  `tfsdk:"num_pools"`
+	Pools     types.List   `tfsdk:"pools"`
+}
+
+type ReplicaGroupPartitionConfig struct {
+	ReplicaGroupBased           types.Bool   `tfsdk:"replica_group_based"`
+	NumInstances                types.Int64  `tfsdk:"num_instances"`
+	NumReplicaGroups            types.Int64  `tfsdk:"num_replica_groups"`
+	NumInstancesPerReplicaGroup types.Int64  `tfsdk:"num_instances_per_replica_group"`
+	NumPartitions               types.Int64  `tfsdk:"num_partitions"`
+	NumInstancesPerPartition    types.Int64  `tfsdk:"num_instances_per_partition"`
+	PartitionColumn             types.String `tfsdk:"partition_column"`
+	MinimizeDataMovement        types.Bool   `tfsdk:"minimize_data_movement"`
+}
+
+type ConstraintConfig struct {
+	Constraints types.List `tfsdk:"constraints"`
+}
+
+type InstanceAssignmentConfig struct {
+	TagPoolConfig               *TagPoolConfig               `tfsdk:"tag_pool_config"`
+	ReplicaGroupPartitionConfig *ReplicaGroupPartitionConfig `tfsdk:"replica_group_partition_config"`
+	ConstraintConfig            *ConstraintConfig            `tfsdk:"constraint_config"`
+	PartitionSelector           types.String                 `tfsdk:"partition_selector"`
+	MinimizeDataMovement        types.Bool                   `tfsdk:"minimize_data_movement"`
+}
+
+type SegmentAssignmentConfig struct {
+	AssignmentStrategy types.String `tfsdk:"assignment_strategy"`
+}
+
+type RoutingConfig struct {
+	SegmentPrunerTypes   types.List   `tfsdk:"segment_pruner_types"`
+	InstanceSelectorType types.String `tfsdk:"instance_selector_type"`
+}
+
+type QueryConfig struct {
+	TimeoutMs                 types.Int64 `tfsdk:"timeout_ms"`
+	ExpressionOverrideMap     types.Map   `tfsdk:"expression_override_map"`
+	MaxQueryResponseSizeBytes types.Int64 `tfsdk:"max_query_response_size_bytes"`
+}
+
+type QuotaConfig struct {
+	Storage             types.String  `tfsdk:"storage"`
+	MaxQueriesPerSecond types.Float64 `tfsdk:"max_queries_per_second"`
+}
+
 type Metadata struct {
 	CustomConfigs map[string]string `tfsdk:"custom_configs"`
 }
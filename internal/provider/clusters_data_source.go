@@ -2,11 +2,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,8 +31,16 @@ type clustersDataSource struct {
 }
 
 type clustersDataSourceModel struct {
-	ClusterName   string             `tfsdk:"cluster_name"`
-	ClusterConfig clusterConfigModel `tfsdk:"cluster_config"`
+	ClusterName      string             `tfsdk:"cluster_name"`
+	ClusterConfig    clusterConfigModel `tfsdk:"cluster_config"`
+	Configs          map[string]string  `tfsdk:"configs"`
+	ControllerLeader string             `tfsdk:"controller_leader"`
+	Versions         map[string]string  `tfsdk:"versions"`
+	Healthy          bool               `tfsdk:"healthy"`
+	HealthStatus     string             `tfsdk:"health_status"`
+	NumLiveInstances int64              `tfsdk:"num_live_instances"`
+	NumDeadInstances int64              `tfsdk:"num_dead_instances"`
+	DeadInstances    []string           `tfsdk:"dead_instances"`
 }
 
 type clusterConfigModel struct {
@@ -87,6 +100,41 @@ func (d *clustersDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					},
 				},
 			},
+			"configs": schema.MapAttribute{
+				Description: "All configs of the Pinot cluster.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"controller_leader": schema.StringAttribute{
+				Description: "The controller that is the Helix leader of the cluster.",
+				Computed:    true,
+			},
+			"versions": schema.MapAttribute{
+				Description: "The version of each Pinot component, as reported by the controller.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"healthy": schema.BoolAttribute{
+				Description: "Whether the controller reports itself as healthy and all instances are connected to the cluster.",
+				Computed:    true,
+			},
+			"health_status": schema.StringAttribute{
+				Description: "The health status the controller reports, e.g. OK.",
+				Computed:    true,
+			},
+			"num_live_instances": schema.Int64Attribute{
+				Description: "The number of instances that are connected to the cluster.",
+				Computed:    true,
+			},
+			"num_dead_instances": schema.Int64Attribute{
+				Description: "The number of instances that are not connected to the cluster.",
+				Computed:    true,
+			},
+			"dead_instances": schema.ListAttribute{
+				Description: "The instances that are not connected to the cluster, sorted by name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -105,16 +153,72 @@ func (d *clustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 	state.ClusterName = clusterNameResp.ClusterName
 
 	// Get Cluster Config
-	clusterConfigResp, err := d.client.GetClusterConfigs()
+	err = d.client.FetchData("/cluster/configs", &state.Configs)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get cluster config", err.Error())
 		return
 	}
 
-	state.ClusterConfig.AllowParticipantAutoJoin = clusterConfigResp.AllowParticipantAutoJoin
-	state.ClusterConfig.EnableCaseInsensitive = clusterConfigResp.EnableCaseInsensitive
-	state.ClusterConfig.DefaultHyperlogLogLog2m = clusterConfigResp.DefaultHyperlogLogLog2m
-	state.ClusterConfig.PinotBrokerEnableQueryLimitOverride = clusterConfigResp.PinotBrokerEnableQueryLimitOverride
+	state.ClusterConfig.AllowParticipantAutoJoin = state.Configs["allowParticipantAutoJoin"]
+	state.ClusterConfig.EnableCaseInsensitive = state.Configs["enable.case.insensitive"]
+	state.ClusterConfig.DefaultHyperlogLogLog2m = state.Configs["default.hyperloglog.log2m"]
+	state.ClusterConfig.PinotBrokerEnableQueryLimitOverride = state.Configs["pinot.broker.enable.query.limit.override"]
+
+	// Get Controller Leader
+	var leader struct {
+		ID string `json:"id"`
+	}
+	err = d.client.FetchData(fmt.Sprintf("/zk/get?path=/%s/CONTROLLER/LEADER", state.ClusterName), &leader)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get controller leader", err.Error())
+		return
+	}
+
+	state.ControllerLeader = leader.ID
+
+	// Get Versions
+	err = d.client.FetchData("/version", &state.Versions)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get versions", err.Error())
+		return
+	}
+
+	// Get Health
+	var health json.RawMessage
+	err = d.client.FetchData("/health", &health)
+	state.Healthy, state.HealthStatus, err = healthStatus(health, err)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get health", err.Error())
+		return
+	}
+
+	// Get Live Instances
+	instancesResp, err := d.client.GetInstances()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get instances", err.Error())
+		return
+	}
+
+	var liveInstances []string
+	err = d.client.FetchData(fmt.Sprintf("/zk/ls?path=/%s/LIVEINSTANCES", state.ClusterName), &liveInstances)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get live instances", err.Error())
+		return
+	}
+
+	state.DeadInstances = []string{}
+	for _, instance := range instancesResp.Instances {
+		if contains(liveInstances, instance) {
+			state.NumLiveInstances++
+		} else {
+			state.DeadInstances = append(state.DeadInstances, instance)
+		}
+	}
+	sort.Strings(state.DeadInstances)
+	state.NumDeadInstances = int64(len(state.DeadInstances))
+
+	// The controller's /health only covers the controller itself.
+	state.Healthy = state.Healthy && state.NumDeadInstances == 0
 
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -123,3 +227,22 @@ func (d *clustersDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 }
+
+// healthStatus interprets the response of the controller's /health endpoint. The controller answers with plain
+// text, which FetchData fails to decode but includes in its error, and with a 503 when it is unhealthy.
+func healthStatus(body json.RawMessage, err error) (bool, string, error) {
+
+	var syntaxError *json.SyntaxError
+	switch {
+	case err == nil:
+		return true, strings.Trim(string(body), `"`), nil
+	case errors.As(err, &syntaxError):
+		_, status, _ := strings.Cut(err.Error(), "\n")
+		return true, strings.TrimSpace(status), nil
+	case strings.Contains(err.Error(), "status code: 503"):
+		_, status, _ := strings.Cut(err.Error(), "body: ")
+		return false, strings.TrimSpace(status), nil
+	}
+
+	return false, "", err
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestHealthStatus(t *testing.T) {
+
+	err := json.Unmarshal([]byte("OK"), new(json.RawMessage))
+	healthy, status, err := healthStatus(nil, fmt.Errorf("client: could not unmarshal response JSON: %w\n%s", err, "OK"))
+	if err != nil || !healthy || status != "OK" {
+		t.Errorf("expected a healthy OK status, got %t %q %v", healthy, status, err)
+	}
+
+	healthy, status, err = healthStatus(nil, errors.New("client: request failed with status code: 503, body: Pinot controller status is STARTING"))
+	if err != nil || healthy || status != "Pinot controller status is STARTING" {
+		t.Errorf("expected an unhealthy status, got %t %q %v", healthy, status, err)
+	}
+
+	_, _, err = healthStatus(nil, errors.New("client: could not send request: connection refused"))
+	if err == nil {
+		t.Errorf("expected the request error")
+	}
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	goPinotAPI "github.com/azaurus1/go-pinot-api"
+)
+
+const (
+	pollInterval = 5 * time.Second
+
+	// maxConcurrentRequests bounds the requests a data source sends to the controller at once.
+	maxConcurrentRequests = 8
+)
+
+// isNotFound reports whether err is the error go-pinot-api returns when the controller responds with a 404.
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "status code: 404") || strings.Contains(err.Error(), "status 404")
+}
+
+// getLiveInstances returns the instances that are connected to the cluster, as Helix tracks them in ZooKeeper.
+func getLiveInstances(client *goPinotAPI.PinotAPIClient) ([]string, error) {
+
+	clusterInfo, err := client.GetClusterInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	var liveInstances []string
+	err = client.FetchData(fmt.Sprintf("/zk/ls?path=/%s/LIVEINSTANCES", clusterInfo.ClusterName), &liveInstances)
+	if err != nil {
+		return nil, err
+	}
+
+	return liveInstances, nil
+}
+
+// waitFor calls done every pollInterval until it returns true, returns an error or ctx expires.
+func waitFor(ctx context.Context, done func() (bool, error)) error {
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		finished, err := done()
+		if err != nil {
+			return err
+		}
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the controller: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// forEachConcurrently calls fn for every index below count on at most maxConcurrentRequests goroutines and
+// returns the errors of all calls that failed.
+func forEachConcurrently(count int, fn func(i int) error) error {
+
+	indexes := make(chan int)
+	errs := make([]error, count)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < min(count, maxConcurrentRequests); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -3,10 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
+	"github.com/azaurus1/go-pinot-api/model"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
@@ -26,10 +31,64 @@ type instancesDataSource struct {
 	client *goPinotAPI.PinotAPIClient
 }
 
+// instanceTypes are the instance types, each instance name starts with one of them.
+var instanceTypes = []string{"CONTROLLER", "BROKER", "SERVER", "MINION"}
+
 type instancesDataSourceModel struct {
+	Type      types.String     `tfsdk:"type"`
+	Tag       types.String     `tfsdk:"tag"`
+	Pool      types.String     `tfsdk:"pool"`
+	Enabled   types.Bool       `tfsdk:"enabled"`
 	Instances []instancesModel `tfsdk:"instances"`
 }
 
+// pinotInstance is the instance config of the controller. The pinot model declares pools as a list, but the
+// controller returns them as a map from tag to pool number.
+type pinotInstance struct {
+	model.GetInstanceResponse
+	Pools map[string]string `json:"pools"`
+}
+
+// getInstance fetches the instance config of instanceName.
+func getInstance(client *goPinotAPI.PinotAPIClient, instanceName string) (*pinotInstance, error) {
+
+	var instance pinotInstance
+	err := client.FetchData(fmt.Sprintf("/instances/%s", instanceName), &instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+// poolNumbers returns the pool numbers of the instance, sorted.
+func (i *pinotInstance) poolNumbers() []string {
+	pools := []string{}
+	for _, pool := range i.Pools {
+		if !contains(pools, pool) {
+			pools = append(pools, pool)
+		}
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+// matches reports whether instance passes the tag, pool and enabled filters.
+func (m *instancesDataSourceModel) matches(instance *pinotInstance) bool {
+
+	if !m.Tag.IsNull() && !contains(instance.Tags, m.Tag.ValueString()) {
+		return false
+	}
+	if !m.Pool.IsNull() && !contains(instance.poolNumbers(), m.Pool.ValueString()) {
+		return false
+	}
+	if !m.Enabled.IsNull() && instance.Enabled != m.Enabled.ValueBool() {
+		return false
+	}
+
+	return true
+}
+
 type systemResourceInfoModel struct {
 	NumCores      string `tfsdk:"num_cores"`
 	TotalMemoryMB string `tfsdk:"total_memory_mb"`
@@ -38,6 +97,7 @@ type systemResourceInfoModel struct {
 
 type instancesModel struct {
 	InstanceName       string                  `tfsdk:"instance_name"`
+	Type               string                  `tfsdk:"type"`
 	HostName           string                  `tfsdk:"host_name"`
 	Enabled            bool                    `tfsdk:"enabled"`
 	Port               string                  `tfsdk:"port"`
@@ -78,6 +138,22 @@ func (d *instancesDataSource) Metadata(_ context.Context, req datasource.Metadat
 func (d *instancesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Description: "Only return the instances of this type, one of CONTROLLER, BROKER, SERVER or MINION.",
+				Optional:    true,
+			},
+			"tag": schema.StringAttribute{
+				Description: "Only return the instances with this tag, e.g. DefaultTenant_OFFLINE.",
+				Optional:    true,
+			},
+			"pool": schema.StringAttribute{
+				Description: "Only return the instances in the pool with this number, for any of their tags.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only return the instances that are enabled, or disabled when false.",
+				Optional:    true,
+			},
 			"instances": schema.ListNestedAttribute{
 				Description: "The list of instances.",
 				Computed:    true,
@@ -87,6 +163,10 @@ func (d *instancesDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 							Description: "The name of the instance.",
 							Computed:    true,
 						},
+						"type": schema.StringAttribute{
+							Description: "The type of the instance, one of CONTROLLER, BROKER, SERVER or MINION.",
+							Computed:    true,
+						},
 						"host_name": schema.StringAttribute{
 							Description: "The hostname of the instance.",
 							Computed:    true,
@@ -106,7 +186,7 @@ func (d *instancesDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 							ElementType: basetypes.StringType{},
 						},
 						"pools": schema.ListAttribute{
-							Description: "The list of pools.",
+							Description: "The numbers of the pools the instance is in.",
 							Computed:    true,
 							ElementType: basetypes.StringType{},
 						},
@@ -154,6 +234,20 @@ func (d *instancesDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 // Read refreshes the Terraform state with the latest data.
 func (d *instancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state instancesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.Type.IsNull() && !containsFold(instanceTypes, state.Type.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid Instance Type",
+			fmt.Sprintf("%q is not an instance type, expected one of %s.", state.Type.ValueString(), strings.Join(instanceTypes, ", ")),
+		)
+		return
+	}
 
 	instancesResp, err := d.client.GetInstances()
 	if err != nil {
@@ -161,11 +255,33 @@ func (d *instancesDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	// the type is part of the instance name, so instances of other types are not fetched at all
+	var instanceNames []string
 	for _, instance := range instancesResp.Instances {
-		instanceResp, err := d.client.GetInstance(instance)
+		if state.Type.IsNull() || strings.EqualFold(instanceType(instance), state.Type.ValueString()) {
+			instanceNames = append(instanceNames, instance)
+		}
+	}
+
+	instances := make([]*pinotInstance, len(instanceNames))
+	err = forEachConcurrently(len(instanceNames), func(i int) error {
+		instanceResp, err := getInstance(d.client, instanceNames[i])
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to get instance", fmt.Sprintf("Failed to get instance: %s", err))
-			return
+			return fmt.Errorf("instance %s: %w", instanceNames[i], err)
+		}
+		instances[i] = instanceResp
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get instance", fmt.Sprintf("Failed to get instance: %s", err))
+		return
+	}
+
+	state.Instances = []instancesModel{}
+	for _, instanceResp := range instances {
+
+		if !state.matches(instanceResp) {
+			continue
 		}
 
 		systemInfo := systemResourceInfoModel{
@@ -176,11 +292,12 @@ func (d *instancesDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 		state.Instances = append(state.Instances, instancesModel{
 			InstanceName:       instanceResp.InstanceName,
+			Type:               instanceType(instanceResp.InstanceName),
 			HostName:           instanceResp.Hostname,
 			Enabled:            instanceResp.Enabled,
 			Port:               instanceResp.Port,
 			Tags:               instanceResp.Tags,
-			Pools:              instanceResp.Pools,
+			Pools:              instanceResp.poolNumbers(),
 			GRPCPort:           instanceResp.GRPCPort,
 			AdminPort:          instanceResp.AdminPort,
 			QueryServicePort:   instanceResp.QueryServicePort,
@@ -190,10 +307,16 @@ func (d *instancesDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	}
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 }
+
+// instanceType returns the type an instance name starts with, e.g. SERVER for Server_pinot-server-0_8098.
+func instanceType(instanceName string) string {
+	prefix, _, _ := strings.Cut(instanceName, "_")
+	return strings.ToUpper(prefix)
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInstanceType(t *testing.T) {
+
+	instanceTypes := map[string]string{
+		"Controller_pinot-controller-0_9000": "CONTROLLER",
+		"Broker_pinot-broker-0_8099":         "BROKER",
+		"Server_pinot-server-0_8098":         "SERVER",
+		"Minion_pinot-minion-0_9514":         "MINION",
+	}
+
+	for instanceName, expected := range instanceTypes {
+		if actual := instanceType(instanceName); actual != expected {
+			t.Errorf("expected %s for %s, got %s", expected, instanceName, actual)
+		}
+	}
+}
+
+func TestInstancesDataSourceMatches(t *testing.T) {
+
+	var instance pinotInstance
+	err := json.Unmarshal([]byte(`{
+		"instanceName": "Server_pinot-server-0_8098",
+		"hostname": "pinot-server-0",
+		"enabled": true,
+		"port": "8098",
+		"tags": ["DefaultTenant_OFFLINE", "DefaultTenant_REALTIME"],
+		"pools": {"DefaultTenant_OFFLINE": "0", "DefaultTenant_REALTIME": "1"}
+	}`), &instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pools := instance.poolNumbers(); len(pools) != 2 || pools[0] != "0" || pools[1] != "1" {
+		t.Errorf("expected pools [0 1], got %v", pools)
+	}
+
+	filters := []struct {
+		name     string
+		filter   instancesDataSourceModel
+		expected bool
+	}{
+		{"no filter", instancesDataSourceModel{}, true},
+		{"matching tag", instancesDataSourceModel{Tag: types.StringValue("DefaultTenant_REALTIME")}, true},
+		{"other tag", instancesDataSourceModel{Tag: types.StringValue("OtherTenant_REALTIME")}, false},
+		{"matching pool", instancesDataSourceModel{Pool: types.StringValue("1")}, true},
+		{"other pool", instancesDataSourceModel{Pool: types.StringValue("2")}, false},
+		{"pool tag is not a pool", instancesDataSourceModel{Pool: types.StringValue("DefaultTenant_OFFLINE")}, false},
+		{"enabled", instancesDataSourceModel{Enabled: types.BoolValue(true)}, true},
+		{"disabled", instancesDataSourceModel{Enabled: types.BoolValue(false)}, false},
+		{"all matching", instancesDataSourceModel{
+			Tag:     types.StringValue("DefaultTenant_OFFLINE"),
+			Pool:    types.StringValue("0"),
+			Enabled: types.BoolValue(true),
+		}, true},
+	}
+
+	for _, f := range filters {
+		if actual := f.filter.matches(&instance); actual != f.expected {
+			t.Errorf("%s: expected %t, got %t", f.name, f.expected, actual)
+		}
+	}
+}
@@ -182,6 +182,9 @@ func (p *pinotProvider) DataSources(_ context.Context) []func() datasource.DataS
 		NewTenantsDataSource,
 		NewClustersDataSource,
 		NewInstancesDataSource,
+		NewSchemaDataSource,
+		NewSchemasDataSource,
+		NewTableDataSource,
 	}
 }
 
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	goPinotAPI "github.com/azaurus1/go-pinot-api"
+	"github.com/azaurus1/go-pinot-api/model"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &schemaDataSource{}
+	_ datasource.DataSourceWithConfigure = &schemaDataSource{}
+)
+
+// NewSchemaDataSource is a helper function to simplify the provider implementation.
+func NewSchemaDataSource() datasource.DataSource {
+	return &schemaDataSource{}
+}
+
+// schemaDataSource is the data source implementation.
+type schemaDataSource struct {
+	client *goPinotAPI.PinotAPIClient
+}
+
+type schemaDataSourceModel struct {
+	SchemaName                    types.String         `tfsdk:"schema_name"`
+	EnableColumnBasedNullHandling basetypes.BoolValue  `tfsdk:"enable_column_based_null_handling"`
+	DimensionFieldSpecs           []dimensionFieldSpec `tfsdk:"dimension_field_specs"`
+	MetricFieldSpecs              []metricFieldSpec    `tfsdk:"metric_field_specs"`
+	DateTimeFieldSpecs            []dateTimeFieldSpec  `tfsdk:"date_time_field_specs"`
+	PrimaryKeyColumns             []string             `tfsdk:"primary_key_columns"`
+	SchemaJSON                    types.String         `tfsdk:"schema_json"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *schemaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*goPinotAPI.PinotAPIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *goPinotAPI.PinotAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *schemaDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schema"
+}
+
+// Schema defines the schema for the data source.
+func (d *schemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := schemaAttributes()
+	attributes["schema_name"] = schema.StringAttribute{
+		Description: "The name of the schema.",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *schemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state schemaDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schemaState, err := getSchemaState(d.client, state.SchemaName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get schema", fmt.Sprintf("Failed to get schema %s: %s", state.SchemaName.ValueString(), err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, schemaState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+// getSchemaState fetches a schema and converts it like the pinot_schema resource does, keeping the raw JSON as well.
+func getSchemaState(client *goPinotAPI.PinotAPIClient, schemaName string) (*schemaDataSourceModel, error) {
+
+	var rawSchema json.RawMessage
+	err := client.FetchData(fmt.Sprintf("/schemas/%s", schemaName), &rawSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaState(rawSchema)
+}
+
+// schemaState converts a schema as returned by the controller into the data source model.
+func schemaState(rawSchema json.RawMessage) (*schemaDataSourceModel, error) {
+
+	var pinotSchema model.Schema
+	err := json.Unmarshal(rawSchema, &pinotSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaJSON bytes.Buffer
+	err = json.Indent(&schemaJSON, rawSchema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceState tableSchemaResourceModel
+	setState(&resourceState, &pinotSchema)
+
+	return &schemaDataSourceModel{
+		SchemaName:                    resourceState.SchemaName,
+		EnableColumnBasedNullHandling: resourceState.EnableColumnBasedNullHandling,
+		DimensionFieldSpecs:           resourceState.DimensionFieldSpecs,
+		MetricFieldSpecs:              resourceState.MetricFieldSpecs,
+		DateTimeFieldSpecs:            resourceState.DateTimeFieldSpecs,
+		PrimaryKeyColumns:             resourceState.PrimaryKeyColumns,
+		SchemaJSON:                    types.StringValue(schemaJSON.String()),
+	}, nil
+}
+
+// schemaAttributes returns the computed attributes of a schema, shared by the pinot_schema and pinot_schemas data sources.
+func schemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"schema_name": schema.StringAttribute{
+			Description: "The name of the schema.",
+			Computed:    true,
+		},
+		"enable_column_based_null_handling": schema.BoolAttribute{
+			Description: "Whether column based null handling is enabled.",
+			Computed:    true,
+		},
+		"dimension_field_specs": schema.ListNestedAttribute{
+			Description: "The dimension field specs.",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the dimension.",
+						Computed:    true,
+					},
+					"data_type": schema.StringAttribute{
+						Description: "The data type of the dimension.",
+						Computed:    true,
+					},
+					"not_null": schema.BoolAttribute{
+						Description: "Whether the dimension is not null.",
+						Computed:    true,
+					},
+					"single_value_field": schema.BoolAttribute{
+						Description: "Whether the dimension is a single value field.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"metric_field_specs": schema.ListNestedAttribute{
+			Description: "The metric field specs.",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the metric.",
+						Computed:    true,
+					},
+					"data_type": schema.StringAttribute{
+						Description: "The data type of the metric.",
+						Computed:    true,
+					},
+					"not_null": schema.BoolAttribute{
+						Description: "Whether the metric is not null.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"date_time_field_specs": schema.ListNestedAttribute{
+			Description: "The date time field specs.",
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Description: "The name of the date time column.",
+						Computed:    true,
+					},
+					"data_type": schema.StringAttribute{
+						Description: "The data type of the date time column.",
+						Computed:    true,
+					},
+					"not_null": schema.BoolAttribute{
+						Description: "Whether the date time column is not null.",
+						Computed:    true,
+					},
+					"format": schema.StringAttribute{
+						Description: "The format of the date time.",
+						Computed:    true,
+					},
+					"granularity": schema.StringAttribute{
+						Description: "The granularity of the date time.",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"primary_key_columns": schema.ListAttribute{
+			Description: "The primary key columns.",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+		"schema_json": schema.StringAttribute{
+			Description: "The schema as returned by the controller, for example to copy into a table definition with jsondecode.",
+			Computed:    true,
+		},
+	}
+}
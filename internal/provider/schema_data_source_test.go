@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaDataSourceState(t *testing.T) {
+
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	(&schemaDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	rawSchema := json.RawMessage(`{"schemaName":"events","enableColumnBasedNullHandling":false,` +
+		`"dimensionFieldSpecs":[{"name":"id","dataType":"STRING"},{"name":"tags","dataType":"STRING","singleValueField":false}],` +
+		`"metricFieldSpecs":[{"name":"amount","dataType":"DOUBLE"}],` +
+		`"dateTimeFieldSpecs":[{"name":"ts","dataType":"LONG","format":"1:MILLISECONDS:EPOCH","granularity":"1:MILLISECONDS"}],` +
+		`"primaryKeyColumns":["id"]}`)
+
+	schemaModel, err := schemaState(rawSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	diags := state.Set(ctx, schemaModel)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var dimensionFieldSpecs []dimensionFieldSpec
+	state.GetAttribute(ctx, path.Root("dimension_field_specs"), &dimensionFieldSpecs)
+	if len(dimensionFieldSpecs) != 2 || dimensionFieldSpecs[1].Name != "tags" || dimensionFieldSpecs[1].SingleValueField.ValueBool() {
+		t.Errorf("expected the multi value dimension tags, got %v", dimensionFieldSpecs)
+	}
+
+	var primaryKeyColumns []string
+	state.GetAttribute(ctx, path.Root("primary_key_columns"), &primaryKeyColumns)
+	if len(primaryKeyColumns) != 1 || primaryKeyColumns[0] != "id" {
+		t.Errorf("expected primary key column id, got %v", primaryKeyColumns)
+	}
+
+	// the raw schema is kept as returned, only indented
+	var schemaJSON types.String
+	state.GetAttribute(ctx, path.Root("schema_json"), &schemaJSON)
+
+	var decoded map[string]any
+	err = json.Unmarshal([]byte(schemaJSON.ValueString()), &decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded["schemaName"] != "events" || len(decoded["metricFieldSpecs"].([]any)) != 1 {
+		t.Errorf("expected the schema as returned by the controller, got %s", schemaJSON)
+	}
+	if !strings.HasPrefix(schemaJSON.ValueString(), "{\n  \"") {
+		t.Errorf("expected indented JSON, got %s", schemaJSON)
+	}
+}
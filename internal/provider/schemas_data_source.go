@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	goPinotAPI "github.com/azaurus1/go-pinot-api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &schemasDataSource{}
+	_ datasource.DataSourceWithConfigure = &schemasDataSource{}
+)
+
+// NewSchemasDataSource is a helper function to simplify the provider implementation.
+func NewSchemasDataSource() datasource.DataSource {
+	return &schemasDataSource{}
+}
+
+// schemasDataSource is the data source implementation.
+type schemasDataSource struct {
+	client *goPinotAPI.PinotAPIClient
+}
+
+type schemasDataSourceModel struct {
+	Names       []string                `tfsdk:"names"`
+	NamePattern types.String            `tfsdk:"name_pattern"`
+	Schemas     []schemaDataSourceModel `tfsdk:"schemas"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *schemasDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*goPinotAPI.PinotAPIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *goPinotAPI.PinotAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *schemasDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schemas"
+}
+
+// Schema defines the schema for the data source.
+func (d *schemasDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"names": schema.SetAttribute{
+				Description: "Only return the schemas with these names.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"name_pattern": schema.StringAttribute{
+				Description: "Only return the schemas whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"schemas": schema.ListNestedAttribute{
+				Description: "The schemas, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: schemaAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *schemasDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state schemasDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var namePattern *regexp.Regexp
+	if !state.NamePattern.IsNull() {
+		var err error
+		namePattern, err = regexp.Compile(state.NamePattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_pattern"), "Invalid Name Pattern", err.Error())
+			return
+		}
+	}
+
+	schemaNames, err := d.client.GetSchemas()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get schemas", fmt.Sprintf("Failed to get schemas: %s", err))
+		return
+	}
+
+	names := []string(*schemaNames)
+	sort.Strings(names)
+
+	state.Schemas = []schemaDataSourceModel{}
+	for _, name := range names {
+
+		if state.Names != nil && !contains(state.Names, name) {
+			continue
+		}
+		if namePattern != nil && !namePattern.MatchString(name) {
+			continue
+		}
+
+		schemaState, err := getSchemaState(d.client, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get schema", fmt.Sprintf("Failed to get schema %s: %s", name, err))
+			return
+		}
+
+		state.Schemas = append(state.Schemas, *schemaState)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
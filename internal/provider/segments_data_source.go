@@ -3,6 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -26,15 +31,38 @@ type segmentsDataSource struct {
 	client *goPinotAPI.PinotAPIClient
 }
 
+// segmentTimeUnits converts the segment.time.unit of the segment metadata to a duration.
+var segmentTimeUnits = map[string]time.Duration{
+	"NANOSECONDS":  time.Nanosecond,
+	"MICROSECONDS": time.Microsecond,
+	"MILLISECONDS": time.Millisecond,
+	"SECONDS":      time.Second,
+	"MINUTES":      time.Minute,
+	"HOURS":        time.Hour,
+	"DAYS":         24 * time.Hour,
+}
+
 type segmentsDataSourceModel struct {
 	TableName        types.String    `tfsdk:"table_name"`
+	FromTimeMs       types.Int64     `tfsdk:"from_time_ms"`
+	ToTimeMs         types.Int64     `tfsdk:"to_time_ms"`
+	Statuses         []string        `tfsdk:"statuses"`
 	OfflineSegments  []segmentsModel `tfsdk:"offline_segments"`
 	RealtimeSegments []segmentsModel `tfsdk:"realtime_segments"`
 }
 
 type segmentsModel struct {
 	// TableName   string `tfsdk:"table_name"`
-	SegmentName string `tfsdk:"segment_name"`
+	SegmentName    string       `tfsdk:"segment_name"`
+	SizeInBytes    types.Int64  `tfsdk:"size_in_bytes"`
+	CRC            types.String `tfsdk:"crc"`
+	StartTimeMs    types.Int64  `tfsdk:"start_time_ms"`
+	EndTimeMs      types.Int64  `tfsdk:"end_time_ms"`
+	TotalDocs      types.Int64  `tfsdk:"total_docs"`
+	CreationTimeMs types.Int64  `tfsdk:"creation_time_ms"`
+	Status         types.String `tfsdk:"status"`
+	Tier           types.String `tfsdk:"tier"`
+	Servers        []string     `tfsdk:"servers"`
 }
 
 // Configure adds the provider configured client to the data source.
@@ -63,34 +91,82 @@ func (d *segmentsDataSource) Metadata(_ context.Context, req datasource.Metadata
 
 // Schema returns the data source schema.
 func (d *segmentsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+
+	segmentAttributes := map[string]schema.Attribute{
+		"segment_name": schema.StringAttribute{
+			Description: "The name of the segment.",
+			Computed:    true,
+		},
+		"size_in_bytes": schema.Int64Attribute{
+			Description: "The size of the segment, null for controllers that do not record it.",
+			Computed:    true,
+		},
+		"crc": schema.StringAttribute{
+			Description: "The CRC of the segment.",
+			Computed:    true,
+		},
+		"start_time_ms": schema.Int64Attribute{
+			Description: "The earliest time value in the segment, in epoch milliseconds.",
+			Computed:    true,
+		},
+		"end_time_ms": schema.Int64Attribute{
+			Description: "The latest time value in the segment, in epoch milliseconds.",
+			Computed:    true,
+		},
+		"total_docs": schema.Int64Attribute{
+			Description: "The number of documents in the segment.",
+			Computed:    true,
+		},
+		"creation_time_ms": schema.Int64Attribute{
+			Description: "When the segment was created, in epoch milliseconds.",
+			Computed:    true,
+		},
+		"status": schema.StringAttribute{
+			Description: "The status of the segment, e.g. IN_PROGRESS for a consuming segment, DONE for a committed one or UPLOADED for a pushed one.",
+			Computed:    true,
+		},
+		"tier": schema.StringAttribute{
+			Description: "The storage tier the segment is on, null for the default tier.",
+			Computed:    true,
+		},
+		"servers": schema.ListAttribute{
+			Description: "The servers the segment is assigned to.",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	}
+
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"table_name": schema.StringAttribute{
 				Description: "The name of the table to get segments for",
 				Required:    true,
 			},
+			"from_time_ms": schema.Int64Attribute{
+				Description: "Only return the segments with data at or after this time, in epoch milliseconds.",
+				Optional:    true,
+			},
+			"to_time_ms": schema.Int64Attribute{
+				Description: "Only return the segments with data at or before this time, in epoch milliseconds.",
+				Optional:    true,
+			},
+			"statuses": schema.SetAttribute{
+				Description: "Only return the segments with one of these statuses.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"offline_segments": schema.ListNestedAttribute{
 				Description: "The list of offline segments.",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"segment_name": schema.StringAttribute{
-							Description: "The name of the segment.",
-							Computed:    true,
-						},
-					},
+					Attributes: segmentAttributes,
 				},
 			},
 			"realtime_segments": schema.ListNestedAttribute{
 				Description: "The list of realtime segments.",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"segment_name": schema.StringAttribute{
-							Description: "The name of the segment.",
-							Computed:    true,
-						},
-					},
+					Attributes: segmentAttributes,
 				},
 			},
 		},
@@ -107,39 +183,147 @@ func (d *segmentsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	segments, err := d.client.GetSegments(state.TableName.ValueString())
+	tableName := state.TableName.ValueString()
+
+	segments, err := d.client.GetSegments(tableName)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get segments", err.Error())
 		return
 	}
 
+	var offlineSegmentNames, realtimeSegmentNames []string
 	for _, segment := range segments {
+		offlineSegmentNames = append(offlineSegmentNames, segment.Offline...)
+		realtimeSegmentNames = append(realtimeSegmentNames, segment.Realtime...)
+	}
+
+	state.OfflineSegments, err = d.getSegments(tableName+"_OFFLINE", offlineSegmentNames)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get offline segments", err.Error())
+		return
+	}
+
+	state.RealtimeSegments, err = d.getSegments(tableName+"_REALTIME", realtimeSegmentNames)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get realtime segments", err.Error())
+		return
+	}
+
+	state.OfflineSegments = state.filter(state.OfflineSegments)
+	state.RealtimeSegments = state.filter(state.RealtimeSegments)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 
-		// create slices for offline and realtime segments per segment
-		offlineSegments := make([]segmentsModel, 0)
-		realtimeSegments := make([]segmentsModel, 0)
+}
+
+// getSegments fetches the metadata and servers of the named segments of a table.
+func (d *segmentsDataSource) getSegments(tableNameWithType string, segmentNames []string) ([]segmentsModel, error) {
+
+	segments := make([]segmentsModel, len(segmentNames))
+	if len(segmentNames) == 0 {
+		return segments, nil
+	}
 
-		if len(segment.Offline) > 0 {
-			for _, segment := range segment.Offline {
-				offlineSegments = append(offlineSegments, segmentsModel{
-					SegmentName: segment,
-				})
+	var serversResp []struct {
+		ServerToSegmentsMap map[string][]string `json:"serverToSegmentsMap"`
+	}
+	err := d.client.FetchData(fmt.Sprintf("/segments/%s/servers", tableNameWithType), &serversResp)
+	if err != nil {
+		return nil, err
+	}
+
+	segmentServers := map[string][]string{}
+	for _, servers := range serversResp {
+		for server, serverSegments := range servers.ServerToSegmentsMap {
+			for _, segmentName := range serverSegments {
+				segmentServers[segmentName] = append(segmentServers[segmentName], server)
 			}
 		}
+	}
 
-		if len(segment.Realtime) > 0 {
-			for _, segment := range segment.Realtime {
-				realtimeSegments = append(realtimeSegments, segmentsModel{
-					SegmentName: segment,
-				})
-			}
+	err = forEachConcurrently(len(segmentNames), func(i int) error {
+
+		var metadata map[string]any
+		err := d.client.FetchData(fmt.Sprintf("/segments/%s/%s/metadata", tableNameWithType, url.PathEscape(segmentNames[i])), &metadata)
+		if err != nil {
+			return fmt.Errorf("segment %s: %w", segmentNames[i], err)
+		}
+
+		servers := segmentServers[segmentNames[i]]
+		sort.Strings(servers)
+
+		segments[i] = segmentsModel{
+			SegmentName:    segmentNames[i],
+			SizeInBytes:    metadataInt64(metadata, "segment.size.in.bytes"),
+			CRC:            metadataString(metadata, "segment.crc"),
+			StartTimeMs:    metadataTimeMs(metadata, "segment.start.time"),
+			EndTimeMs:      metadataTimeMs(metadata, "segment.end.time"),
+			TotalDocs:      metadataInt64(metadata, "segment.total.docs"),
+			CreationTimeMs: metadataInt64(metadata, "segment.creation.time"),
+			Status:         metadataString(metadata, "segment.realtime.status"),
+			Tier:           metadataString(metadata, "segment.tier"),
+			Servers:        servers,
+		}
+
+		// only realtime segments record a status, the others are pushed
+		if segments[i].Status.IsNull() {
+			segments[i].Status = types.StringValue("UPLOADED")
 		}
 
-		state.OfflineSegments = offlineSegments
-		state.RealtimeSegments = realtimeSegments
+		return nil
+	})
+
+	return segments, err
+}
+
+// filter returns the segments that match the time range and statuses of the data source.
+func (m *segmentsDataSourceModel) filter(segments []segmentsModel) []segmentsModel {
+
+	filtered := make([]segmentsModel, 0, len(segments))
+	for _, segment := range segments {
+
+		if !m.FromTimeMs.IsNull() && !segment.EndTimeMs.IsNull() && segment.EndTimeMs.ValueInt64() < m.FromTimeMs.ValueInt64() {
+			continue
+		}
+		if !m.ToTimeMs.IsNull() && !segment.StartTimeMs.IsNull() && segment.StartTimeMs.ValueInt64() > m.ToTimeMs.ValueInt64() {
+			continue
+		}
+		if m.Statuses != nil && !containsFold(m.Statuses, segment.Status.ValueString()) {
+			continue
+		}
+
+		filtered = append(filtered, segment)
 	}
 
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	return filtered
+}
+
+// metadataString returns a value of the segment metadata, which the controller returns as strings, or null when missing.
+func metadataString(metadata map[string]any, key string) types.String {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(fmt.Sprint(value))
+}
+
+func metadataInt64(metadata map[string]any, key string) types.Int64 {
+	value, err := strconv.ParseInt(metadataString(metadata, key).ValueString(), 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// metadataTimeMs returns a segment time in epoch milliseconds, the metadata keeps it in segment.time.unit.
+func metadataTimeMs(metadata map[string]any, key string) types.Int64 {
+
+	value := metadataInt64(metadata, key)
+	unit, ok := segmentTimeUnits[strings.ToUpper(metadataString(metadata, "segment.time.unit").ValueString())]
+	if value.IsNull() || !ok {
+		return value
+	}
 
+	return types.Int64Value(time.Duration(value.ValueInt64() * int64(unit)).Milliseconds())
 }
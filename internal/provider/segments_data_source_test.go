@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMetadataTimeMs(t *testing.T) {
+
+	metadata := map[string]any{
+		"segment.start.time": "19723",
+		"segment.end.time":   "19724",
+		"segment.time.unit":  "DAYS",
+	}
+
+	if start := metadataTimeMs(metadata, "segment.start.time"); start.ValueInt64() != 1704067200000 {
+		t.Errorf("expected 2024-01-01 in epoch milliseconds, got %s", start)
+	}
+
+	if missing := metadataTimeMs(metadata, "segment.creation.time"); !missing.IsNull() {
+		t.Errorf("expected null for a missing time, got %s", missing)
+	}
+}
+
+func TestSegmentsFilter(t *testing.T) {
+
+	state := segmentsDataSourceModel{
+		FromTimeMs: types.Int64Value(100),
+		ToTimeMs:   types.Int64Value(200),
+		Statuses:   []string{"DONE"},
+	}
+
+	segments := []segmentsModel{
+		{SegmentName: "before", StartTimeMs: types.Int64Value(0), EndTimeMs: types.Int64Value(99), Status: types.StringValue("DONE")},
+		{SegmentName: "overlapping", StartTimeMs: types.Int64Value(50), EndTimeMs: types.Int64Value(150), Status: types.StringValue("DONE")},
+		{SegmentName: "consuming", StartTimeMs: types.Int64Value(150), EndTimeMs: types.Int64Null(), Status: types.StringValue("IN_PROGRESS")},
+		{SegmentName: "after", StartTimeMs: types.Int64Value(201), EndTimeMs: types.Int64Value(300), Status: types.StringValue("DONE")},
+	}
+
+	filtered := state.filter(segments)
+	if len(filtered) != 1 || filtered[0].SegmentName != "overlapping" {
+		t.Errorf("expected only the overlapping segment, got %v", filtered)
+	}
+}
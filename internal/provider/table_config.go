@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"terraform-provider-pinot/internal/converter"
+	"terraform-provider-pinot/internal/customtypes"
+	"terraform-provider-pinot/internal/models"
+
+	goPinotAPI "github.com/azaurus1/go-pinot-api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// setValue sets key in m to the Go value of value, leaving m untouched when value is null or unknown.
+func setValue(ctx context.Context, m map[string]any, key string, value attr.Value) {
+
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		m[key] = v.ValueString()
+	case types.Bool:
+		m[key] = v.ValueBool()
+	case types.Int64:
+		m[key] = v.ValueInt64()
+	case types.Float64:
+		m[key] = v.ValueFloat64()
+	case types.List:
+		if v.ElementType(ctx).Equal(types.Int64Type) {
+			var values []int64
+			v.ElementsAs(ctx, &values, false)
+			m[key] = values
+			break
+		}
+		m[key] = toStringList(ctx, v)
+	case types.Map:
+		var values map[string]string
+		v.ElementsAs(ctx, &values, false)
+		m[key] = values
+	}
+}
+
+// mergeTableConfig deep-merges overrides into tableConfig. Nested objects are merged key by key,
+// any other value in overrides replaces the one in tableConfig.
+func mergeTableConfig(tableConfig map[string]any, overrides map[string]any) (map[string]any, error) {
+
+	// round trip through JSON so typed values from the pinot models merge like the decoded table config
+	overridesBytes, err := json.Marshal(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	var decodedOverrides map[string]any
+	err = json.Unmarshal(overridesBytes, &decodedOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeMaps(tableConfig, decodedOverrides), nil
+}
+
+func mergeMaps(base map[string]any, overrides map[string]any) map[string]any {
+
+	merged := make(map[string]any, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range overrides {
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overrideMap, overrideIsMap := value.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[key] = mergeMaps(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// lookup returns the value at the given keys of a table config, or nil when any of them is missing.
+func lookup(tableConfig map[string]any, keys ...string) any {
+
+	var value any = tableConfig
+	for _, key := range keys {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+
+	return value
+}
+
+// streamConfigs returns the stream config maps of a table config, including the legacy tableIndexConfig.streamConfigs.
+func streamConfigs(tableConfig map[string]any) []map[string]any {
+
+	var configs []map[string]any
+
+	streamConfigMaps, _ := lookup(tableConfig, "ingestionConfig", "streamIngestionConfig", "streamConfigMaps").([]any)
+	for _, streamConfigMap := range streamConfigMaps {
+		if streamConfig, ok := streamConfigMap.(map[string]any); ok {
+			configs = append(configs, streamConfig)
+		}
+	}
+
+	if streamConfig, ok := lookup(tableConfig, "tableIndexConfig", "streamConfigs").(map[string]any); ok {
+		configs = append(configs, streamConfig)
+	}
+
+	return configs
+}
+
+// withStreamSecrets adds the stream secrets to every stream config of tableConfig and returns it.
+func withStreamSecrets(ctx context.Context, tableConfig map[string]any, streamSecrets types.Map) map[string]any {
+
+	var secrets map[string]string
+	streamSecrets.ElementsAs(ctx, &secrets, false)
+
+	for _, streamConfig := range streamConfigs(tableConfig) {
+		for key, value := range secrets {
+			streamConfig[key] = value
+		}
+	}
+
+	return tableConfig
+}
+
+// withoutStreamSecrets removes the keys of the stream secrets from every stream config of tableConfig.
+func withoutStreamSecrets(tableConfig map[string]any, streamSecrets types.Map) {
+
+	for _, streamConfig := range streamConfigs(tableConfig) {
+		for key := range streamSecrets.Elements() {
+			delete(streamConfig, key)
+		}
+	}
+}
+
+// withoutStreamCredentials removes the keys that hold credentials from every stream config of tableConfig.
+func withoutStreamCredentials(tableConfig map[string]any) {
+
+	for _, streamConfig := range streamConfigs(tableConfig) {
+		for key := range streamConfig {
+			if isStreamSecretKey(key) {
+				delete(streamConfig, key)
+			}
+		}
+	}
+}
+
+// streamSecretsDrift compares the stream secrets on the controller with the configured ones by SHA-256 hash.
+// A secret that differs is replaced with the hash of the live value, so the plan shows a change without the
+// live secret ending up in state.
+func streamSecretsDrift(ctx context.Context, tableConfig map[string]any, streamSecrets types.Map) types.Map {
+
+	var secrets map[string]string
+	streamSecrets.ElementsAs(ctx, &secrets, false)
+	if len(secrets) == 0 {
+		return streamSecrets
+	}
+
+	configs := streamConfigs(tableConfig)
+
+	drifted := false
+	for key, value := range secrets {
+		liveHash := ""
+		for _, streamConfig := range configs {
+			if liveValue, ok := streamConfig[key]; ok {
+				liveHash = hashSecret(fmt.Sprint(liveValue))
+				break
+			}
+		}
+
+		if liveHash != hashSecret(value) {
+			secrets[key] = "sha256:" + liveHash
+			drifted = true
+		}
+	}
+
+	if !drifted {
+		return streamSecrets
+	}
+
+	driftedSecrets, _ := types.MapValueFrom(ctx, types.StringType, secrets)
+	return driftedSecrets
+}
+
+func hashSecret(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// getTableConfig fetches the table config of the given type as returned by the controller.
+// It returns nil when the table does not exist.
+func getTableConfig(client *goPinotAPI.PinotAPIClient, tableName string, tableType string) (map[string]any, error) {
+
+	tableType = strings.ToUpper(tableType)
+
+	var tables map[string]map[string]any
+	err := client.FetchData(fmt.Sprintf("/tables/%s?type=%s", tableName, strings.ToLower(tableType)), &tables)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return tables[tableType], nil
+}
+
+// immutableTableConfigFields are the fields the controller cannot change on an existing table.
+var immutableTableConfigFields = [][]string{
+	{"tableType"},
+	{"segmentsConfig", "timeColumnName"},
+	{"upsertConfig", "mode"},
+}
+
+// immutableTableConfigChanges returns the immutable fields that differ between the prior and the planned table config.
+func immutableTableConfigChanges(prior map[string]any, planned map[string]any) []string {
+
+	var changes []string
+	for _, keys := range immutableTableConfigFields {
+		priorValue, plannedValue := immutableValue(prior, keys), immutableValue(planned, keys)
+		if !strings.EqualFold(priorValue, plannedValue) {
+			changes = append(changes, strings.Join(keys, "."))
+		}
+	}
+
+	return changes
+}
+
+// immutableValue returns the value at keys, a table without upsert has the upsert mode NONE.
+func immutableValue(tableConfig map[string]any, keys []string) string {
+
+	value, _ := lookup(tableConfig, keys...).(string)
+	if value == "" && keys[0] == "upsertConfig" {
+		return "NONE"
+	}
+
+	return value
+}
+
+// tableConfigsEqual reports whether two table configs are the same once controller defaults are ignored.
+func tableConfigsEqual(a map[string]any, b map[string]any) bool {
+
+	aBytes, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	bBytes, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	equal, err := customtypes.TableConfigsEqual(string(aBytes), string(bBytes))
+	if err != nil {
+		return false
+	}
+
+	return equal
+}
+
+// equivalentTableConfig keeps the table definition in state when the configured one describes the same table
+// config. The framework only applies semantic equality to the values Create, Read and Update return, so without
+// it reformatting the definition would still plan an update.
+type equivalentTableConfig struct{}
+
+func (m equivalentTableConfig) Description(_ context.Context) string {
+	return "Keeps the table definition in state when the configured one only differs in formatting, key order or controller defaults."
+}
+
+func (m equivalentTableConfig) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m equivalentTableConfig) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+
+	if req.StateValue.IsNull() || req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	equal, err := customtypes.TableConfigsEqual(req.StateValue.ValueString(), req.ConfigValue.ValueString())
+	if err == nil && equal {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// keepConfiguredAttributes clears the structured attributes that are not set in prior, the table definition
+// already carries their values and setting them would show a diff against config that leaves them out. Blocks that
+// are set only keep the nested attributes that are set in prior.
+func keepConfiguredAttributes(prior *models.TableResourceModel, state *models.TableResourceModel) {
+
+	if prior.TenantsConfig != nil && state.TenantsConfig != nil {
+		// the pinot model has no tag override config, changes to it show up in the table definition
+		state.TenantsConfig.TagOverrideConfig = prior.TenantsConfig.TagOverrideConfig
+	}
+
+	if prior.IngestionConfig != nil && state.IngestionConfig != nil && state.IngestionConfig.StreamIngestionConfig != nil &&
+		prior.IngestionConfig.StreamIngestionConfig != nil && prior.IngestionConfig.StreamIngestionConfig.Kafka != nil {
+		converter.SplitKafkaStreamConfig(context.Background(), state.IngestionConfig.StreamIngestionConfig)
+	}
+
+	keepConfiguredValues(reflect.ValueOf(prior).Elem(), reflect.ValueOf(state).Elem())
+}
+
+// keepConfiguredValues walks prior and state, which have the same type, and clears every attribute of state that is
+// null in prior. Nested blocks, list elements and map entries are walked the same way.
+func keepConfiguredValues(prior reflect.Value, state reflect.Value) {
+
+	if priorValue, ok := prior.Interface().(attr.Value); ok {
+		if priorValue.IsNull() {
+			state.Set(prior)
+		}
+		return
+	}
+
+	switch prior.Kind() {
+	case reflect.Struct:
+		for i := 0; i < prior.NumField(); i++ {
+			keepConfiguredValues(prior.Field(i), state.Field(i))
+		}
+	case reflect.Pointer:
+		if prior.IsNil() || state.IsNil() {
+			state.Set(reflect.Zero(state.Type()))
+			return
+		}
+		keepConfiguredValues(prior.Elem(), state.Elem())
+	case reflect.Slice:
+		if prior.IsNil() {
+			state.Set(reflect.Zero(state.Type()))
+			return
+		}
+		// elements are matched by position, a list of another length shows up as a change anyway
+		if prior.Len() == state.Len() {
+			for i := 0; i < prior.Len(); i++ {
+				keepConfiguredValues(prior.Index(i), state.Index(i))
+			}
+		}
+	case reflect.Map:
+		if prior.IsNil() {
+			state.Set(reflect.Zero(state.Type()))
+			return
+		}
+		if state.IsNil() {
+			return
+		}
+		for _, key := range state.MapKeys() {
+			priorEntry := prior.MapIndex(key)
+			if !priorEntry.IsValid() {
+				continue
+			}
+			// map entries are not addressable, walk a copy and store it back
+			entry := reflect.New(state.Type().Elem()).Elem()
+			entry.Set(state.MapIndex(key))
+			keepConfiguredValues(priorEntry, entry)
+			state.SetMapIndex(key, entry)
+		}
+	}
+}
+
+// effectiveConfigJSON renders the table config normalized like the table definition: without the controller's table
+// name suffix, empty values and controller defaults. Planned and live configs then only differ where the table does.
+// Stream credentials are left out, the effective config is not sensitive.
+func effectiveConfigJSON(tableConfig map[string]any) (string, error) {
+
+	tableConfigBytes, err := json.Marshal(tableConfig)
+	if err != nil {
+		return "", err
+	}
+
+	effectiveConfig, err := customtypes.NormalizeTableConfig(string(tableConfigBytes))
+	if err != nil {
+		return "", err
+	}
+	withoutStreamCredentials(effectiveConfig)
+
+	effectiveConfigBytes, err := json.MarshalIndent(effectiveConfig, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(effectiveConfigBytes), nil
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"terraform-provider-pinot/internal/converter"
+	"terraform-provider-pinot/internal/customtypes"
+	"terraform-provider-pinot/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOverrideKeepsUnmodelledFields(t *testing.T) {
+
+	plan := models.TableResourceModel{
+		TableName: types.StringValue("events"),
+		TableType: types.StringValue("OFFLINE"),
+		Table:     customtypes.NewTableConfigValue(`{"tableName":"events","tableType":"OFFLINE","segmentsConfig":{"replication":"1","timeColumnName":"ts"},"routing":{"instanceSelectorType":"replicaGroup"}}`),
+		SegmentsConfig: &models.SegmentsConfig{
+			TimeType:       types.StringValue("MILLISECONDS"),
+			Replication:    types.StringValue("3"),
+			TimeColumnName: types.StringValue("ts"),
+		},
+		IsDimTable: types.BoolNull(),
+	}
+
+	tableConfig, err := override(&plan)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var expected map[string]any
+	err = json.Unmarshal([]byte(`{
+		"tableName": "events",
+		"tableType": "OFFLINE",
+		"segmentsConfig": {"replication": "3", "timeColumnName": "ts", "timeType": "MILLISECONDS"},
+		"routing": {"instanceSelectorType": "replicaGroup"}
+	}`), &expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(tableConfig, expected) {
+		t.Errorf("expected %v, got %v", expected, tableConfig)
+	}
+}
+
+func TestImmutableTableConfigChanges(t *testing.T) {
+
+	var prior map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events",
+		"tableType": "REALTIME",
+		"segmentsConfig": {"replication": "1", "timeColumnName": "ts", "timeType": "MILLISECONDS"},
+		"upsertConfig": {"mode": "FULL", "hashFunction": "NONE"}
+	}`), &prior)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	table := customtypes.NewTableConfigValue(`{"tableName":"events","tableType":"REALTIME","segmentsConfig":{"replication":"1","timeColumnName":"ts"},"upsertConfig":{"mode":"FULL"}}`)
+
+	testCases := map[string]struct {
+		plan     models.TableResourceModel
+		expected []string
+	}{
+		"blocks added with the same values": {
+			plan: models.TableResourceModel{
+				Table: table,
+				SegmentsConfig: &models.SegmentsConfig{
+					TimeType:       types.StringValue("MILLISECONDS"),
+					Replication:    types.StringValue("2"),
+					TimeColumnName: types.StringValue("ts"),
+				},
+				UpsertConfig: &models.UpsertConfig{
+					Mode:                    types.StringValue("full"),
+					PartialUpsertStrategies: types.MapNull(types.StringType),
+				},
+			},
+		},
+		"time column changed by a block": {
+			plan: models.TableResourceModel{
+				Table: table,
+				SegmentsConfig: &models.SegmentsConfig{
+					TimeType:       types.StringValue("MILLISECONDS"),
+					Replication:    types.StringValue("1"),
+					TimeColumnName: types.StringValue("created_at"),
+				},
+			},
+			expected: []string{"segmentsConfig.timeColumnName"},
+		},
+		"upsert removed from the table definition": {
+			plan: models.TableResourceModel{
+				Table: customtypes.NewTableConfigValue(`{"tableName":"events","tableType":"REALTIME","segmentsConfig":{"replication":"1","timeColumnName":"ts"}}`),
+			},
+			expected: []string{"upsertConfig.mode"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			testCase.plan.TableName = types.StringValue("events")
+			testCase.plan.TableType = types.StringValue("REALTIME")
+			testCase.plan.IsDimTable = types.BoolNull()
+
+			planned, err := override(&testCase.plan)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			changes := immutableTableConfigChanges(prior, planned)
+			if !reflect.DeepEqual(changes, testCase.expected) {
+				t.Errorf("expected changes %v, got %v", testCase.expected, changes)
+			}
+		})
+	}
+}
+
+func TestEffectiveConfigJSONIgnoresDefaults(t *testing.T) {
+
+	planned := map[string]any{
+		"tableName":      "events",
+		"tableType":      "OFFLINE",
+		"segmentsConfig": map[string]any{"replication": "2", "timeColumnName": "ts"},
+	}
+
+	var live map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_OFFLINE",
+		"tableType": "OFFLINE",
+		"segmentsConfig": {"replication": "2", "timeColumnName": "ts", "minimizeDataMovement": false},
+		"tenants": {"broker": "DefaultTenant", "server": "DefaultTenant"},
+		"tableIndexConfig": {"loadMode": "MMAP", "rangeIndexVersion": 2, "invertedIndexColumns": []},
+		"metadata": {},
+		"isDimTable": false
+	}`), &live)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	plannedJSON, err := effectiveConfigJSON(planned)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	liveJSON, err := effectiveConfigJSON(live)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if plannedJSON != liveJSON {
+		t.Errorf("expected the planned config %s to match the live config, got %s", plannedJSON, liveJSON)
+	}
+}
+
+func TestEffectiveConfigJSONWithoutCredentials(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{"tableName":"events","ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{
+		"streamType": "kafka",
+		"stream.kafka.consumer.prop.sasl.jaas.config": "set outside terraform"
+	}]}}}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	effectiveConfig, err := effectiveConfigJSON(tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Contains(effectiveConfig, "sasl.jaas.config") {
+		t.Errorf("expected the credential to be left out, got %s", effectiveConfig)
+	}
+
+	// the config is still sent to the controller as given
+	if _, ok := streamConfigs(tableConfig)[0]["stream.kafka.consumer.prop.sasl.jaas.config"]; !ok {
+		t.Error("expected the table config to be left unchanged")
+	}
+}
+
+func TestStreamSecrets(t *testing.T) {
+
+	ctx := context.Background()
+
+	secrets, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"sasl.jaas.config": "secret"})
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{"streamType":"kafka"}]}}}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	withStreamSecrets(ctx, tableConfig, secrets)
+
+	if streamConfigs(tableConfig)[0]["sasl.jaas.config"] != "secret" {
+		t.Fatalf("expected the secret to be added, got %v", streamConfigs(tableConfig))
+	}
+
+	if drift := streamSecretsDrift(ctx, tableConfig, secrets); !drift.Equal(secrets) {
+		t.Errorf("expected no drift, got %s", drift)
+	}
+
+	streamConfigs(tableConfig)[0]["sasl.jaas.config"] = "rotated"
+
+	if drift := streamSecretsDrift(ctx, tableConfig, secrets); drift.Equal(secrets) {
+		t.Error("expected drift after the secret changed on the controller")
+	}
+
+	withoutStreamSecrets(tableConfig, secrets)
+
+	if _, ok := streamConfigs(tableConfig)[0]["sasl.jaas.config"]; ok {
+		t.Error("expected the secret to be removed")
+	}
+}
+
+func TestEquivalentTableConfig(t *testing.T) {
+
+	state := types.StringValue(`{"tableName":"events","tableType":"OFFLINE","segmentsConfig":{"replication":"3","timeColumnName":"ts"}}`)
+
+	testCases := map[string]struct {
+		config   types.String
+		expected types.String
+	}{
+		"reformatted": {
+			config:   types.StringValue("{\n  \"tableType\": \"OFFLINE\",\n  \"tableName\": \"events\",\n  \"segmentsConfig\": {\"timeColumnName\": \"ts\", \"replication\": \"3\"}\n}"),
+			expected: state,
+		},
+		"changed": {
+			config:   types.StringValue(`{"tableName":"events","tableType":"OFFLINE","segmentsConfig":{"replication":"2","timeColumnName":"ts"}}`),
+			expected: types.StringValue(`{"tableName":"events","tableType":"OFFLINE","segmentsConfig":{"replication":"2","timeColumnName":"ts"}}`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{StateValue: state, ConfigValue: testCase.config, PlanValue: testCase.config}
+			resp := planmodifier.StringResponse{PlanValue: testCase.config}
+
+			equivalentTableConfig{}.PlanModifyString(context.Background(), req, &resp)
+			if !resp.PlanValue.Equal(testCase.expected) {
+				t.Errorf("expected plan %s, got %s", testCase.expected, resp.PlanValue)
+			}
+		})
+	}
+}
+
+func TestKeepConfiguredAttributesPartialBlock(t *testing.T) {
+
+	ctx := context.Background()
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_OFFLINE",
+		"tableType": "OFFLINE",
+		"segmentsConfig": {"replication": "1", "timeColumnName": "ts", "timeType": "MILLISECONDS"},
+		"tenants": {"broker": "DefaultTenant", "server": "DefaultTenant"},
+		"tableIndexConfig": {"loadMode": "MMAP"},
+		"routing": {"instanceSelectorType": "replicaGroup", "segmentPrunerTypes": ["partition"]}
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	prior := models.TableResourceModel{
+		TableName: types.StringValue("events"),
+		TableType: types.StringValue("OFFLINE"),
+		Routing: &models.RoutingConfig{
+			SegmentPrunerTypes:   types.ListNull(types.StringType),
+			InstanceSelectorType: types.StringValue("replicaGroup"),
+		},
+		TaskConfig:            types.MapNull(types.MapType{ElemType: types.StringType}),
+		IsDimTable:            types.BoolNull(),
+		InstancePartitionsMap: types.MapNull(types.StringType),
+	}
+
+	state := prior
+	err = converter.SetStateFromTableConfig(ctx, &state, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	keepConfiguredAttributes(&prior, &state)
+
+	if state.SegmentsConfig != nil || state.TenantsConfig != nil || state.TableIndexConfig != nil {
+		t.Errorf("expected the blocks that are not configured to be cleared, got %+v", state)
+	}
+
+	if state.Routing == nil || state.Routing.InstanceSelectorType.ValueString() != "replicaGroup" {
+		t.Fatalf("expected the configured instance selector type, got %+v", state.Routing)
+	}
+
+	if !state.Routing.SegmentPrunerTypes.IsNull() {
+		t.Errorf("expected the segment pruner types that are not configured to be cleared, got %s", state.Routing.SegmentPrunerTypes)
+	}
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"terraform-provider-pinot/internal/converter"
+	"terraform-provider-pinot/internal/models"
+
+	goPinotAPI "github.com/azaurus1/go-pinot-api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &tableDataSource{}
+	_ datasource.DataSourceWithConfigure = &tableDataSource{}
+)
+
+// tableResourceOnlyAttributes are the pinot_table attributes that only make sense on the resource. The table
+// definition is returned as table_json instead.
+var tableResourceOnlyAttributes = map[string]bool{
+	"table":                      true,
+	"stream_secrets":             true,
+	"detect_stream_secret_drift": true,
+	"effective_config_json":      true,
+	"delete_schema":              true,
+	"timeouts":                   true,
+}
+
+// NewTableDataSource is a helper function to simplify the provider implementation.
+func NewTableDataSource() datasource.DataSource {
+	return &tableDataSource{}
+}
+
+// tableDataSource is the data source implementation.
+type tableDataSource struct {
+	client *goPinotAPI.PinotAPIClient
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *tableDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*goPinotAPI.PinotAPIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *goPinotAPI.PinotAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *tableDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+// Schema defines the schema for the data source. Apart from name, type and table_json it has the attributes of
+// the pinot_table resource, all computed, so the two stay in step.
+func (d *tableDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+
+	var tableSchema resource.SchemaResponse
+	(&tableResource{}).Schema(ctx, resource.SchemaRequest{}, &tableSchema)
+
+	attributes := map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Description: "The name of the table, without the type suffix.",
+			Required:    true,
+		},
+		"type": schema.StringAttribute{
+			Description: "The type of the table, OFFLINE or REALTIME.",
+			Required:    true,
+		},
+		"table_json": schema.StringAttribute{
+			Description: "The table config as returned by the controller, without the stream configs that hold credentials.",
+			Computed:    true,
+		},
+	}
+
+	for name, attribute := range tableSchema.Schema.Attributes {
+		if tableResourceOnlyAttributes[name] {
+			continue
+		}
+		attributes[name] = computedAttribute(attribute)
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Reads an existing table without managing it.",
+		Attributes:  attributes,
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *tableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+
+	var name, tableType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &tableType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tableConfig, err := getTableConfig(d.client, name.ValueString(), tableType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get table", fmt.Sprintf("Failed to get table %s: %s", name.ValueString(), err))
+		return
+	}
+
+	if tableConfig == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Table Not Found",
+			fmt.Sprintf("There is no %s table %s.", strings.ToUpper(tableType.ValueString()), name.ValueString()),
+		)
+		return
+	}
+
+	// the data source has no stream_secrets to hold them, and neither output is sensitive
+	withoutStreamCredentials(tableConfig)
+
+	tableJSON, err := json.MarshalIndent(tableConfig, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to marshal table", err.Error())
+		return
+	}
+
+	var table models.TableResourceModel
+	err = converter.SetStateFromTableConfig(ctx, &table, tableConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to convert table", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), tableType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_json"), string(tableJSON))...)
+	resp.Diagnostics.Append(setTableAttributes(ctx, &resp.State, &table)...)
+}
+
+// setTableAttributes sets the attributes the data source shares with the pinot_table resource from table.
+func setTableAttributes(ctx context.Context, state *tfsdk.State, table *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	value := reflect.ValueOf(table).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Tag.Get("tfsdk")
+		if name == "" || tableResourceOnlyAttributes[name] {
+			continue
+		}
+		diags.Append(state.SetAttribute(ctx, path.Root(name), value.Field(i).Interface())...)
+	}
+
+	return diags
+}
+
+// computedAttribute converts a resource attribute to the computed data source attribute of the same type.
+func computedAttribute(attribute resourceschema.Attribute) schema.Attribute {
+
+	switch a := attribute.(type) {
+	case resourceschema.StringAttribute:
+		return schema.StringAttribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive, CustomType: a.CustomType}
+	case resourceschema.BoolAttribute:
+		return schema.BoolAttribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive}
+	case resourceschema.Int64Attribute:
+		return schema.Int64Attribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive}
+	case resourceschema.Float64Attribute:
+		return schema.Float64Attribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive}
+	case resourceschema.ListAttribute:
+		return schema.ListAttribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive, ElementType: a.ElementType}
+	case resourceschema.SetAttribute:
+		return schema.SetAttribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive, ElementType: a.ElementType}
+	case resourceschema.MapAttribute:
+		return schema.MapAttribute{Description: a.Description, Computed: true, Sensitive: a.Sensitive, ElementType: a.ElementType}
+	case resourceschema.SingleNestedAttribute:
+		return schema.SingleNestedAttribute{Description: a.Description, Computed: true, Attributes: computedAttributes(a.Attributes)}
+	case resourceschema.ListNestedAttribute:
+		return schema.ListNestedAttribute{Description: a.Description, Computed: true, NestedObject: computedNestedObject(a.NestedObject)}
+	case resourceschema.SetNestedAttribute:
+		return schema.SetNestedAttribute{Description: a.Description, Computed: true, NestedObject: computedNestedObject(a.NestedObject)}
+	case resourceschema.MapNestedAttribute:
+		return schema.MapNestedAttribute{Description: a.Description, Computed: true, NestedObject: computedNestedObject(a.NestedObject)}
+	}
+
+	panic(fmt.Sprintf("unsupported attribute type %T", attribute))
+}
+
+func computedAttributes(attributes map[string]resourceschema.Attribute) map[string]schema.Attribute {
+
+	computed := make(map[string]schema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		computed[name] = computedAttribute(attribute)
+	}
+
+	return computed
+}
+
+func computedNestedObject(nestedObject resourceschema.NestedAttributeObject) schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{Attributes: computedAttributes(nestedObject.Attributes)}
+}
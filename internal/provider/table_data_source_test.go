@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"terraform-provider-pinot/internal/converter"
+	"terraform-provider-pinot/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTableDataSourceState(t *testing.T) {
+
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	(&tableDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{
+		"tableName": "events_REALTIME",
+		"tableType": "REALTIME",
+		"segmentsConfig": {"replication": "1", "timeColumnName": "ts", "timeType": "MILLISECONDS"},
+		"tenants": {"broker": "DefaultTenant", "server": "DefaultTenant"},
+		"tableIndexConfig": {"loadMode": "MMAP"},
+		"ingestionConfig": {
+			"streamIngestionConfig": {
+				"streamConfigMaps": [{"streamType": "kafka", "stream.kafka.topic.name": "events"}]
+			}
+		}
+	}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var table models.TableResourceModel
+	err = converter.SetStateFromTableConfig(ctx, &table, tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	diags := setTableAttributes(ctx, &state, &table)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var timeColumnName types.String
+	state.GetAttribute(ctx, path.Root("segments_config").AtName("time_column_name"), &timeColumnName)
+	if timeColumnName.ValueString() != "ts" {
+		t.Errorf("expected time column ts, got %s", timeColumnName)
+	}
+
+	var streamConfigMaps []map[string]string
+	state.GetAttribute(ctx, path.Root("ingestion_config").AtName("stream_ingestion_config").AtName("stream_config_maps"), &streamConfigMaps)
+	if len(streamConfigMaps) != 1 || streamConfigMaps[0]["stream.kafka.topic.name"] != "events" {
+		t.Errorf("expected the topic in the stream config, got %v", streamConfigMaps)
+	}
+}
+
+func TestWithoutStreamCredentials(t *testing.T) {
+
+	var tableConfig map[string]any
+	err := json.Unmarshal([]byte(`{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{
+		"stream.kafka.topic.name": "events",
+		"stream.kafka.consumer.prop.sasl.jaas.config": "org.apache.kafka.common.security.plain.PlainLoginModule required password=\"secret\";",
+		"stream.kafka.consumer.prop.ssl.keystore.password": "secret"
+	}]}}}`), &tableConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	withoutStreamCredentials(tableConfig)
+
+	streamConfig := streamConfigs(tableConfig)[0]
+	if len(streamConfig) != 1 || streamConfig["stream.kafka.topic.name"] != "events" {
+		t.Errorf("expected only the topic to be left, got %v", streamConfig)
+	}
+}
@@ -0,0 +1,681 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"terraform-provider-pinot/internal/converter"
+	"terraform-provider-pinot/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	partitionFunctions          = []string{"Modulo", "Murmur", "Murmur3", "FNV", "ByteArray", "HashCode", "BoundedColumnValue"}
+	fstTypes                    = []string{"LUCENE", "NATIVE"}
+	vectorDistanceFunctions     = []string{"COSINE", "EUCLIDEAN", "INNER_PRODUCT", "DOT_PRODUCT"}
+	segmentPrunerTypes          = []string{"partition", "time", "empty"}
+	instanceSelectorTypes       = []string{"balanced", "replicaGroup", "strictReplicaGroup", "multiStageReplicaGroup"}
+	hashFunctions               = []string{"NONE", "MD5", "MURMUR3"}
+	assignmentStrategies        = []string{"balanced", "replicaGroup", "dimTable"}
+	segmentIngestionTypes       = []string{"APPEND", "REFRESH"}
+	segmentIngestionFrequencies = []string{"DAILY", "HOURLY"}
+	collectionToJSONModes       = []string{"NONE", "NON_PRIMITIVE", "ALL"}
+	kafkaConsumerTypes          = []string{"lowlevel", "highlevel"}
+	kafkaSecurityProtocols      = []string{"PLAINTEXT", "SSL", "SASL_PLAINTEXT", "SASL_SSL"}
+	kafkaRequiredKeys           = []string{"stream.kafka.topic.name", "stream.kafka.broker.list", "stream.kafka.decoder.class.name"}
+	segmentSelectorTypes        = []string{"time", "fixed"}
+	tierStorageTypes            = []string{"PINOT_SERVER"}
+	storageQuotaPattern         = regexp.MustCompile(`^(?i)\d+(\.\d+)?[KMGTPE]?B?$`)
+)
+
+func validateSegmentPartitionConfig(config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if config.TableIndexConfig == nil || config.TableIndexConfig.SegmentPartitionConfig == nil {
+		return diags
+	}
+
+	for column, partitionConfig := range config.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap {
+
+		columnPath := path.Root("table_index_config").AtName("segment_partition_config").AtName("column_partition_map").AtMapKey(column)
+
+		functionName := partitionConfig.FunctionName
+		if !functionName.IsUnknown() && !functionName.IsNull() && !containsFold(partitionFunctions, functionName.ValueString()) {
+			diags.AddAttributeError(
+				columnPath.AtName("function_name"),
+				"Invalid Partition Function",
+				fmt.Sprintf("%q is not a partition function, expected one of %s.", functionName.ValueString(), strings.Join(partitionFunctions, ", ")),
+			)
+		}
+
+		numPartitions := partitionConfig.NumPartitions
+		if !numPartitions.IsUnknown() && !numPartitions.IsNull() && numPartitions.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				columnPath.AtName("num_partitions"),
+				"Invalid Number of Partitions",
+				fmt.Sprintf("num_partitions must be at least 1, got %d.", numPartitions.ValueInt64()),
+			)
+		}
+	}
+
+	return diags
+}
+
+func validateFieldConfigList(config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	for i, fieldConfig := range config.FieldConfigList {
+
+		if fieldConfig.Indexes == nil {
+			continue
+		}
+
+		indexesPath := path.Root("field_config_list").AtListIndex(i).AtName("indexes")
+		indexes := fieldConfig.Indexes
+
+		if indexes.Text != nil {
+			diags.Append(validateOneOf(indexesPath.AtName("text").AtName("fst_type"), indexes.Text.FSTType, fstTypes)...)
+		}
+
+		if indexes.FST != nil {
+			diags.Append(validateOneOf(indexesPath.AtName("fst").AtName("type"), indexes.FST.Type, fstTypes)...)
+		}
+
+		if indexes.Bloom != nil {
+			fpp := indexes.Bloom.FPP
+			if !fpp.IsUnknown() && !fpp.IsNull() && (fpp.ValueFloat64() <= 0 || fpp.ValueFloat64() >= 1) {
+				diags.AddAttributeError(
+					indexesPath.AtName("bloom").AtName("fpp"),
+					"Invalid False Positive Probability",
+					fmt.Sprintf("fpp must be between 0 and 1, got %g.", fpp.ValueFloat64()),
+				)
+			}
+		}
+
+		if indexes.Vector != nil {
+			diags.Append(validateOneOf(indexesPath.AtName("vector").AtName("vector_distance_function"), indexes.Vector.VectorDistanceFunction, vectorDistanceFunctions)...)
+
+			vectorDimension := indexes.Vector.VectorDimension
+			if !vectorDimension.IsUnknown() && !vectorDimension.IsNull() && vectorDimension.ValueInt64() < 1 {
+				diags.AddAttributeError(
+					indexesPath.AtName("vector").AtName("vector_dimension"),
+					"Invalid Vector Dimension",
+					fmt.Sprintf("vector_dimension must be at least 1, got %d.", vectorDimension.ValueInt64()),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+func validateTaskConfig(ctx context.Context, config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if config.TaskConfig.IsNull() || config.TaskConfig.IsUnknown() {
+		return diags
+	}
+
+	var taskConfigs map[string]types.Map
+	diags.Append(config.TaskConfig.ElementsAs(ctx, &taskConfigs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for taskType, taskConfig := range taskConfigs {
+
+		schedule, ok := taskConfig.Elements()["schedule"].(types.String)
+		if !ok || schedule.IsUnknown() || schedule.IsNull() {
+			continue
+		}
+
+		err := validateCronExpression(schedule.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("task_config").AtMapKey(taskType),
+				"Invalid Task Schedule",
+				fmt.Sprintf("The schedule of %s is not a valid Quartz cron expression: %s.", taskType, err),
+			)
+		}
+	}
+
+	return diags
+}
+
+func validateRoutingQueryQuota(ctx context.Context, config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if config.Routing != nil {
+		routingPath := path.Root("routing")
+
+		if !config.Routing.SegmentPrunerTypes.IsUnknown() {
+			var prunerTypes []types.String
+			diags.Append(config.Routing.SegmentPrunerTypes.ElementsAs(ctx, &prunerTypes, false)...)
+			for i, prunerType := range prunerTypes {
+				diags.Append(validateOneOf(routingPath.AtName("segment_pruner_types").AtListIndex(i), prunerType, segmentPrunerTypes)...)
+			}
+		}
+
+		diags.Append(validateOneOf(routingPath.AtName("instance_selector_type"), config.Routing.InstanceSelectorType, instanceSelectorTypes)...)
+	}
+
+	if config.Query != nil {
+		diags.Append(validatePositive(path.Root("query").AtName("timeout_ms"), config.Query.TimeoutMs)...)
+		diags.Append(validatePositive(path.Root("query").AtName("max_query_response_size_bytes"), config.Query.MaxQueryResponseSizeBytes)...)
+	}
+
+	if config.Quota != nil {
+		storage := config.Quota.Storage
+		if !storage.IsUnknown() && !storage.IsNull() && !storageQuotaPattern.MatchString(storage.ValueString()) {
+			diags.AddAttributeError(
+				path.Root("quota").AtName("storage"),
+				"Invalid Storage Quota",
+				fmt.Sprintf("%q is not a data size, expected a number with an optional unit, e.g. 10G or 500M.", storage.ValueString()),
+			)
+		}
+
+		maxQueriesPerSecond := config.Quota.MaxQueriesPerSecond
+		if !maxQueriesPerSecond.IsUnknown() && !maxQueriesPerSecond.IsNull() && maxQueriesPerSecond.ValueFloat64() <= 0 {
+			diags.AddAttributeError(
+				path.Root("quota").AtName("max_queries_per_second"),
+				"Invalid Query Quota",
+				fmt.Sprintf("max_queries_per_second must be greater than 0, got %g.", maxQueriesPerSecond.ValueFloat64()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validatePositive reports an error when value is set to less than 1.
+func validatePositive(attributePath path.Path, value types.Int64) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if value.IsUnknown() || value.IsNull() || value.ValueInt64() > 0 {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Must be greater than 0, got %d.", value.ValueInt64()),
+	)
+
+	return diags
+}
+
+func validateIngestionConfig(ctx context.Context, config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if config.IngestionConfig == nil {
+		return diags
+	}
+
+	ingestionPath := path.Root("ingestion_config")
+
+	if batchIngestionConfig := config.IngestionConfig.BatchIngestionConfig; batchIngestionConfig != nil {
+		diags.Append(validateOneOf(ingestionPath.AtName("batch_ingestion_config").AtName("segment_ingestion_type"), batchIngestionConfig.SegmentIngestionType, segmentIngestionTypes)...)
+		diags.Append(validateOneOf(ingestionPath.AtName("batch_ingestion_config").AtName("segment_ingestion_frequency"), batchIngestionConfig.SegmentIngestionFrequency, segmentIngestionFrequencies)...)
+	}
+
+	if complexTypeConfig := config.IngestionConfig.ComplexTypeConfig; complexTypeConfig != nil {
+		diags.Append(validateOneOf(ingestionPath.AtName("complex_type_config").AtName("collection_not_unnested_to_json"), complexTypeConfig.CollectionNotUnnestedToJSON, collectionToJSONModes)...)
+	}
+
+	if config.IngestionConfig.StreamIngestionConfig != nil {
+		diags.Append(validateStreamIngestionConfig(ctx, config)...)
+	}
+
+	tableType := config.TableType
+	if config.IngestionConfig.AggregationConfigs != nil && !tableType.IsUnknown() && !tableType.IsNull() && !strings.EqualFold(tableType.ValueString(), "REALTIME") {
+		diags.AddAttributeError(
+			ingestionPath.AtName("aggregation_configs"),
+			"Ingestion Aggregation Requires a Realtime Table",
+			"Pinot only aggregates rows at ingestion for REALTIME tables. Remove aggregation_configs or set table_type to REALTIME.",
+		)
+	}
+
+	return diags
+}
+
+func validateStreamIngestionConfig(ctx context.Context, config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	streamPath := path.Root("ingestion_config").AtName("stream_ingestion_config")
+	streamIngestionConfig := config.IngestionConfig.StreamIngestionConfig
+
+	if kafka := streamIngestionConfig.Kafka; kafka != nil {
+
+		kafkaPath := streamPath.AtName("kafka")
+
+		tableType := config.TableType
+		if !tableType.IsUnknown() && !tableType.IsNull() && !strings.EqualFold(tableType.ValueString(), "REALTIME") {
+			diags.AddAttributeError(kafkaPath, "Stream Ingestion Requires a Realtime Table", "Pinot only consumes streams into REALTIME tables. Remove kafka or set table_type to REALTIME.")
+		}
+
+		diags.Append(validateOneOf(kafkaPath.AtName("consumer_type"), kafka.ConsumerType, kafkaConsumerTypes)...)
+		diags.Append(validateOneOf(kafkaPath.AtName("security_protocol"), kafka.SecurityProtocol, kafkaSecurityProtocols)...)
+
+		if !kafka.SaslMechanism.IsNull() && !kafka.SaslMechanism.IsUnknown() && !kafka.SecurityProtocol.IsUnknown() &&
+			!strings.HasPrefix(strings.ToUpper(kafka.SecurityProtocol.ValueString()), "SASL_") {
+			diags.AddAttributeError(
+				kafkaPath.AtName("sasl_mechanism"),
+				"SASL Mechanism Without SASL Protocol",
+				"sasl_mechanism is only used with security_protocol SASL_PLAINTEXT or SASL_SSL.",
+			)
+		}
+
+		if !kafka.FlushThresholdRows.IsNull() && !kafka.FlushThresholdRows.IsUnknown() && kafka.FlushThresholdRows.ValueInt64() == 0 && kafka.FlushThresholdSegmentSize.IsNull() {
+			diags.AddAttributeError(
+				kafkaPath.AtName("flush_threshold_segment_size"),
+				"Missing Flush Threshold Segment Size",
+				"flush_threshold_rows of 0 commits segments by size, so flush_threshold_segment_size must be set.",
+			)
+		}
+
+		if !kafka.SecurityProperties.IsUnknown() {
+			for key := range kafka.SecurityProperties.Elements() {
+				if !converter.IsKafkaSecurityProperty(key) {
+					diags.AddAttributeError(
+						kafkaPath.AtName("security_properties").AtMapKey(key),
+						"Invalid Security Property",
+						fmt.Sprintf("%q is not a Kafka security property, keys must start with ssl. or sasl.. Use stream_config_maps for other keys.", key),
+					)
+				}
+			}
+		}
+	}
+
+	// a typo in a key only shows up when consumption silently fails, so check the merged stream configs
+	for i, streamConfig := range streamConfigMaps(ctx, streamIngestionConfig) {
+
+		streamConfigPath := streamPath.AtName("stream_config_maps").AtListIndex(i)
+		if i == 0 && streamIngestionConfig.Kafka != nil {
+			streamConfigPath = streamPath.AtName("kafka")
+		}
+
+		streamType, ok := streamConfig["streamType"]
+		if !ok {
+			diags.AddAttributeError(streamConfigPath, "Missing Stream Type", "Every stream config needs a streamType, e.g. kafka.")
+			continue
+		}
+
+		if streamType != "kafka" {
+			continue
+		}
+
+		for _, key := range kafkaRequiredKeys {
+			if _, ok := streamConfig[key]; !ok {
+				diags.AddAttributeError(
+					streamConfigPath,
+					"Missing Kafka Stream Config",
+					fmt.Sprintf("A kafka stream config needs %s.", key),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// streamSecretKeyParts mark the stream config keys that hold credentials, for example sasl.jaas.config,
+// ssl.keystore.password or the basic auth user info of a schema registry.
+var streamSecretKeyParts = []string{"sasl.jaas.config", "password", "secret", "basic.auth.user.info"}
+
+// isStreamSecretKey reports whether a stream config key holds a credential.
+func isStreamSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range streamSecretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateStreamSecrets(ctx context.Context, config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	diags.Append(validateTableStreamSecrets(config)...)
+	diags.Append(validateStreamConfigCredentials(config)...)
+
+	if config.StreamSecrets.IsNull() || config.StreamSecrets.IsUnknown() {
+		return diags
+	}
+
+	tableType := config.TableType
+	if !tableType.IsUnknown() && !tableType.IsNull() && !strings.EqualFold(tableType.ValueString(), "REALTIME") {
+		diags.AddAttributeError(path.Root("stream_secrets"), "Stream Secrets Require a Realtime Table", "Only REALTIME tables have stream configs. Remove stream_secrets or set table_type to REALTIME.")
+	}
+
+	if config.IngestionConfig == nil || config.IngestionConfig.StreamIngestionConfig == nil {
+		return diags
+	}
+
+	// a key in both places would be sent with the secret value but read back into stream_config_maps as a diff
+	for _, streamConfig := range streamConfigMaps(ctx, config.IngestionConfig.StreamIngestionConfig) {
+		for key := range config.StreamSecrets.Elements() {
+			if _, ok := streamConfig[key]; ok {
+				diags.AddAttributeError(
+					path.Root("stream_secrets").AtMapKey(key),
+					"Stream Secret Also Set in Stream Config",
+					fmt.Sprintf("%s is set in both stream_secrets and ingestion_config.stream_ingestion_config, set it in stream_secrets only.", key),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateTableStreamSecrets warns about credentials in the stream configs of the table definition. The table
+// definition is not sensitive, so they show up in every plan.
+func validateTableStreamSecrets(config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if config.Table.IsNull() || config.Table.IsUnknown() {
+		return diags
+	}
+
+	// invalid JSON is reported with the other checks of the table definition
+	var tableConfig map[string]any
+	if json.Unmarshal([]byte(config.Table.ValueString()), &tableConfig) != nil {
+		return diags
+	}
+
+	secrets := config.StreamSecrets.Elements()
+	reported := map[string]bool{}
+	for _, streamConfig := range streamConfigs(tableConfig) {
+		for key := range streamConfig {
+			if reported[key] {
+				continue
+			}
+
+			if _, ok := secrets[key]; ok {
+				reported[key] = true
+				diags.AddAttributeWarning(
+					path.Root("stream_secrets").AtMapKey(key),
+					"Stream Secret Also Set in Table Definition",
+					fmt.Sprintf("%s is set in both stream_secrets and the table definition. The value from stream_secrets is sent, remove it from the table definition.", key),
+				)
+				continue
+			}
+
+			if isStreamSecretKey(key) {
+				reported[key] = true
+				diags.AddAttributeWarning(
+					path.Root("table"),
+					"Stream Secret in Table Definition",
+					fmt.Sprintf("The stream config %s of the table definition looks like a credential and is shown in plans. Move it to stream_secrets.", key),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateStreamConfigCredentials warns about credentials in stream_config_maps and kafka.security_properties.
+// Both are stored in state and refreshed from the controller, stream_config_maps is also shown in plans.
+func validateStreamConfigCredentials(config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if config.IngestionConfig == nil || config.IngestionConfig.StreamIngestionConfig == nil {
+		return diags
+	}
+
+	// keys also set in stream_secrets are reported as duplicates
+	secrets := config.StreamSecrets.Elements()
+	streamIngestionConfigPath := path.Root("ingestion_config").AtName("stream_ingestion_config")
+
+	for i, streamConfig := range config.IngestionConfig.StreamIngestionConfig.StreamConfigMaps {
+		for key := range streamConfig {
+			if _, ok := secrets[key]; ok || !isStreamSecretKey(key) {
+				continue
+			}
+			diags.AddAttributeWarning(
+				streamIngestionConfigPath.AtName("stream_config_maps").AtListIndex(i).AtMapKey(key),
+				"Stream Secret in Stream Config",
+				fmt.Sprintf("The stream config %s looks like a credential and is shown in plans. Move it to stream_secrets.", key),
+			)
+		}
+	}
+
+	kafka := config.IngestionConfig.StreamIngestionConfig.Kafka
+	if kafka == nil || kafka.SecurityProperties.IsNull() || kafka.SecurityProperties.IsUnknown() {
+		return diags
+	}
+
+	for key := range kafka.SecurityProperties.Elements() {
+		if _, ok := secrets[key]; ok || !isStreamSecretKey(key) {
+			continue
+		}
+		diags.AddAttributeWarning(
+			streamIngestionConfigPath.AtName("kafka").AtName("security_properties").AtMapKey(key),
+			"Stream Secret in Security Properties",
+			fmt.Sprintf("The security property %s looks like a credential and is stored in state. Move it to stream_secrets.", key),
+		)
+	}
+
+	return diags
+}
+
+// tableDefinition holds the parts of the table definition that are checked against the other attributes.
+type tableDefinition struct {
+	TableName      string `json:"tableName"`
+	TableType      string `json:"tableType"`
+	SegmentsConfig struct {
+		Replication          any `json:"replication"`
+		ReplicasPerPartition any `json:"replicasPerPartition"`
+	} `json:"segmentsConfig"`
+}
+
+// replication returns the number of replicas of each segment the table is created with, segments_config taking
+// precedence over the table definition. Realtime tables use replicasPerPartition when it is set. It returns 0
+// when the replication is unknown.
+func (t tableDefinition) replication(config *models.TableResourceModel) int64 {
+
+	replication := fmt.Sprint(t.SegmentsConfig.Replication)
+	replicasPerPartition := fmt.Sprint(t.SegmentsConfig.ReplicasPerPartition)
+	if t.SegmentsConfig.ReplicasPerPartition == nil {
+		replicasPerPartition = ""
+	}
+
+	if config.SegmentsConfig != nil {
+		if config.SegmentsConfig.Replication.IsUnknown() || config.SegmentsConfig.ReplicasPerPartition.IsUnknown() {
+			return 0
+		}
+		if !config.SegmentsConfig.Replication.IsNull() {
+			replication = config.SegmentsConfig.Replication.ValueString()
+		}
+		if !config.SegmentsConfig.ReplicasPerPartition.IsNull() {
+			replicasPerPartition = config.SegmentsConfig.ReplicasPerPartition.ValueString()
+		}
+	}
+
+	tableType := t.TableType
+	if !config.TableType.IsNull() && !config.TableType.IsUnknown() {
+		tableType = config.TableType.ValueString()
+	}
+
+	if strings.EqualFold(tableType, "REALTIME") && replicasPerPartition != "" {
+		replication = replicasPerPartition
+	}
+
+	value, err := strconv.ParseInt(replication, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return value
+}
+
+func validateInstanceAssignment(config *models.TableResourceModel, replication int64) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	for instancePartitionsType, assignmentConfig := range config.InstanceAssignmentConfigMap {
+
+		partitionConfig := assignmentConfig.ReplicaGroupPartitionConfig
+		if partitionConfig == nil || !partitionConfig.ReplicaGroupBased.ValueBool() {
+			continue
+		}
+
+		partitionConfigPath := path.Root("instance_assignment_config_map").AtMapKey(instancePartitionsType).AtName("replica_group_partition_config")
+		numReplicaGroups := partitionConfig.NumReplicaGroups
+
+		if numReplicaGroups.IsUnknown() {
+			continue
+		}
+
+		if numReplicaGroups.IsNull() || numReplicaGroups.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				partitionConfigPath.AtName("num_replica_groups"),
+				"Missing Number of Replica Groups",
+				"A replica group based assignment needs num_replica_groups of at least 1.",
+			)
+			continue
+		}
+
+		// every replica group holds one copy of each segment
+		if replication > 0 && numReplicaGroups.ValueInt64() != replication {
+			diags.AddAttributeError(
+				partitionConfigPath.AtName("num_replica_groups"),
+				"Replica Groups Do Not Match Replication",
+				fmt.Sprintf("num_replica_groups is %d but the table has %d replicas. Each replica group holds one replica, so both must match.", numReplicaGroups.ValueInt64(), replication),
+			)
+		}
+	}
+
+	for instancePartitionsType, assignmentConfig := range config.SegmentAssignmentConfigMap {
+		diags.Append(validateOneOf(
+			path.Root("segment_assignment_config_map").AtMapKey(instancePartitionsType).AtName("assignment_strategy"),
+			assignmentConfig.AssignmentStrategy,
+			assignmentStrategies,
+		)...)
+	}
+
+	return diags
+}
+
+// validateTierConfigs checks that every tier has what its segment selector needs and that the tier overwrites
+// name a configured tier, the controller only applies overwrites to tiers it knows about.
+func validateTierConfigs(config *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	tiers := map[string]bool{}
+	for i, tierConfig := range config.TierConfigs {
+
+		tierPath := path.Root("tier_configs").AtListIndex(i)
+
+		diags.Append(validateOneOf(tierPath.AtName("segment_selector_type"), tierConfig.SegmentSelectorType, segmentSelectorTypes)...)
+		diags.Append(validateOneOf(tierPath.AtName("storage_type"), tierConfig.StorageType, tierStorageTypes)...)
+
+		if tierConfig.Name.IsUnknown() {
+			// an unknown name could match any tier overwrite
+			tiers = nil
+		} else if tiers != nil {
+			tiers[tierConfig.Name.ValueString()] = true
+		}
+
+		switch selectorType := tierConfig.SegmentSelectorType; {
+		case selectorType.IsUnknown():
+		case strings.EqualFold(selectorType.ValueString(), "time") && tierConfig.SegmentAge.IsNull():
+			diags.AddAttributeError(
+				tierPath.AtName("segment_age"),
+				"Missing Segment Age",
+				"The time segment selector moves segments older than segment_age to the tier, so segment_age must be set.",
+			)
+		case strings.EqualFold(selectorType.ValueString(), "fixed") && tierConfig.SegmentList.IsNull():
+			diags.AddAttributeError(
+				tierPath.AtName("segment_list"),
+				"Missing Segment List",
+				"The fixed segment selector moves the segments in segment_list to the tier, so segment_list must be set.",
+			)
+		}
+	}
+
+	if config.TableIndexConfig == nil || tiers == nil {
+		return diags
+	}
+
+	for tier := range config.TableIndexConfig.TierOverwrites {
+		if !tiers[tier] {
+			diags.AddAttributeError(
+				path.Root("table_index_config").AtName("tier_overwrites").AtMapKey(tier),
+				"Unknown Tier",
+				fmt.Sprintf("%q is not the name of a tier in tier_configs.", tier),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateCronExpression checks the shape of a Quartz cron expression, the controller rejects the table config otherwise.
+func validateCronExpression(expression string) error {
+
+	fields := strings.Fields(expression)
+	if len(fields) != 6 && len(fields) != 7 {
+		return fmt.Errorf("expected 6 or 7 fields (seconds minutes hours day-of-month month day-of-week [year]), got %d", len(fields))
+	}
+
+	for _, field := range fields {
+		if strings.Trim(field, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz*?,/-#") != "" {
+			return fmt.Errorf("%q contains invalid characters", field)
+		}
+	}
+
+	dayOfMonth, dayOfWeek := fields[3], fields[5]
+	if (dayOfMonth == "?") == (dayOfWeek == "?") {
+		return fmt.Errorf("exactly one of day-of-month and day-of-week must be ?")
+	}
+
+	return nil
+}
+
+// validateOneOf reports an error when value is set to something other than one of values, ignoring case.
+func validateOneOf(attributePath path.Path, value types.String, values []string) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	if value.IsUnknown() || value.IsNull() || containsFold(values, value.ValueString()) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("%q is not valid, expected one of %s.", value.ValueString(), strings.Join(values, ", ")),
+	)
+
+	return diags
+}
+
+// containsFold reports whether values contains value, ignoring case like the controller does.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
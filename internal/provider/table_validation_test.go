@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-pinot/internal/customtypes"
+	"terraform-provider-pinot/internal/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateCronExpression(t *testing.T) {
+
+	testCases := map[string]bool{
+		"0 0 * * * ?":          true,
+		"0 */10 * ? * MON-FRI": true,
+		"0 0 12 1 * ? 2030":    true,
+		"0 * * * *":            false,
+		"0 0 * * * *":          false,
+		"0 0 * ? * ?":          false,
+		"0 0 $ * * ?":          false,
+	}
+
+	for expression, valid := range testCases {
+		t.Run(expression, func(t *testing.T) {
+			err := validateCronExpression(expression)
+			if valid && err != nil {
+				t.Errorf("expected %q to be valid, got %s", expression, err)
+			}
+			if !valid && err == nil {
+				t.Errorf("expected %q to be invalid", expression)
+			}
+		})
+	}
+}
+
+func TestValidateInstanceAssignmentReplicaGroups(t *testing.T) {
+
+	config := models.TableResourceModel{
+		SegmentsConfig: &models.SegmentsConfig{
+			Replication: types.StringValue("3"),
+		},
+		InstanceAssignmentConfigMap: map[string]models.InstanceAssignmentConfig{
+			"OFFLINE": {
+				ReplicaGroupPartitionConfig: &models.ReplicaGroupPartitionConfig{
+					ReplicaGroupBased: types.BoolValue(true),
+					NumReplicaGroups:  types.Int64Value(2),
+				},
+			},
+		},
+	}
+
+	diags := validateInstanceAssignment(&config, tableDefinition{}.replication(&config))
+	if !diags.HasError() {
+		t.Fatal("expected an error when num_replica_groups does not match replication")
+	}
+
+	config.InstanceAssignmentConfigMap["OFFLINE"].ReplicaGroupPartitionConfig.NumReplicaGroups = types.Int64Value(3)
+
+	diags = validateInstanceAssignment(&config, tableDefinition{}.replication(&config))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// realtime tables keep replicasPerPartition replicas of each segment
+	config.TableType = types.StringValue("REALTIME")
+	config.SegmentsConfig.ReplicasPerPartition = types.StringValue("2")
+
+	diags = validateInstanceAssignment(&config, tableDefinition{}.replication(&config))
+	if !diags.HasError() {
+		t.Fatal("expected an error when num_replica_groups does not match replicas_per_partition")
+	}
+}
+
+func TestValidateTierConfigs(t *testing.T) {
+
+	config := models.TableResourceModel{
+		TierConfigs: []*models.TierConfig{
+			{
+				Name:                types.StringValue("coldTier"),
+				SegmentSelectorType: types.StringValue("fixed"),
+				SegmentAge:          types.StringNull(),
+				SegmentList:         types.ListNull(types.StringType),
+				StorageType:         types.StringValue("pinot_server"),
+				ServerTag:           types.StringValue("cold_OFFLINE"),
+			},
+		},
+		TableIndexConfig: &models.TableIndexConfig{
+			TierOverwrites: map[string]models.TierOverwrite{
+				"hotTier": {NoDictionaryColumns: types.ListNull(types.StringType)},
+			},
+		},
+	}
+
+	diags := validateTierConfigs(&config)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected errors for the missing segment list and the unknown tier, got %v", diags)
+	}
+
+	config.TierConfigs[0].SegmentList, _ = types.ListValueFrom(context.Background(), types.StringType, []string{"events_0"})
+	config.TableIndexConfig.TierOverwrites = map[string]models.TierOverwrite{
+		"coldTier": {NoDictionaryColumns: types.ListNull(types.StringType)},
+	}
+
+	diags = validateTierConfigs(&config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestValidateTableStreamSecrets(t *testing.T) {
+
+	ctx := context.Background()
+
+	secrets, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"stream.kafka.consumer.prop.sasl.jaas.config": "secret"})
+
+	config := models.TableResourceModel{
+		TableType: types.StringValue("REALTIME"),
+		Table: customtypes.NewTableConfigValue(`{"ingestionConfig":{"streamIngestionConfig":{"streamConfigMaps":[{
+			"stream.kafka.topic.name": "events",
+			"stream.kafka.consumer.prop.sasl.jaas.config": "inline",
+			"stream.kafka.consumer.prop.ssl.truststore.password": "inline"
+		}]}}}`),
+		StreamSecrets: secrets,
+	}
+
+	diags := validateStreamSecrets(ctx, &config)
+	if diags.HasError() || diags.WarningsCount() != 2 {
+		t.Fatalf("expected a warning for the secret set twice and one for the inline password, got %v", diags)
+	}
+}
+
+func TestValidateStreamConfigCredentials(t *testing.T) {
+
+	ctx := context.Background()
+
+	securityProperties, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"sasl.jaas.config":        "inline",
+		"ssl.truststore.location": "/etc/kafka/truststore.jks",
+	})
+
+	config := models.TableResourceModel{
+		TableType:     types.StringValue("REALTIME"),
+		Table:         customtypes.NewTableConfigNull(),
+		StreamSecrets: types.MapNull(types.StringType),
+		IngestionConfig: &models.IngestionConfig{
+			StreamIngestionConfig: &models.StreamIngestionConfig{
+				StreamConfigMaps: []map[string]string{{
+					"stream.kafka.topic.name":                        "events",
+					"stream.kafka.decoder.prop.basic.auth.user.info": "user:inline",
+				}},
+				Kafka: &models.KafkaStreamConfig{SecurityProperties: securityProperties},
+			},
+		},
+	}
+
+	diags := validateStreamSecrets(ctx, &config)
+	if diags.HasError() || diags.WarningsCount() != 2 {
+		t.Fatalf("expected a warning for the stream config and one for the security property, got %v", diags)
+	}
+}
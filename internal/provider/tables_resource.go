@@ -4,18 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
-	"strconv"
+	"strings"
 	"terraform-provider-pinot/internal/converter"
+	"terraform-provider-pinot/internal/customtypes"
 	"terraform-provider-pinot/internal/models"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
 	"github.com/azaurus1/go-pinot-api/model"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,10 +28,14 @@ import (
 )
 
 var (
-	_ resource.Resource              = &tableResource{}
-	_ resource.ResourceWithConfigure = &tableResource{}
+	_ resource.Resource                   = &tableResource{}
+	_ resource.ResourceWithConfigure      = &tableResource{}
+	_ resource.ResourceWithValidateConfig = &tableResource{}
+	_ resource.ResourceWithModifyPlan     = &tableResource{}
 )
 
+const defaultTableDeleteTimeout = 10 * time.Minute
+
 func NewTableResource() resource.Resource {
 	return &tableResource{}
 }
@@ -57,21 +66,79 @@ func (r *tableResource) Metadata(_ context.Context, req resource.MetadataRequest
 	resp.TypeName = req.ProviderTypeName + "_table"
 }
 
-func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+
+	starTreeIndexConfigs := schema.ListNestedAttribute{
+		Description: "The star tree index configurations for the table.",
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"dimensions_split_order": schema.ListAttribute{
+					Description: "The dimensions split order for the star tree index.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"skip_star_node_creation_for_dim_names": schema.ListAttribute{
+					Description: "The skip star node creation for dim names for the star tree index.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"max_leaf_records": schema.Int64Attribute{
+					Description: "The max leaf records for the star tree index.",
+					Required:    true,
+				},
+				"function_column_pairs": schema.ListAttribute{
+					Description: "The function column pairs for the star tree index.",
+					Optional:    true,
+					ElementType: types.StringType,
+				},
+				"aggregation_configs": schema.ListNestedAttribute{
+					Description: "The aggregation configurations for the star tree index.",
+					Optional:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"column_name": schema.StringAttribute{
+								Description: "The column name for the star tree index.",
+								Required:    true,
+							},
+							"aggregate_function": schema.StringAttribute{
+								Description: "The aggregate function for the star tree index.",
+								Required:    true,
+							},
+							"compression_codec": schema.StringAttribute{
+								Description: "The compression codec for the star tree index.",
+								Required:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"table_name": schema.StringAttribute{
-				Description: "The name of the table.",
+				Description: "The name of the table. Changing this forces a new table to be created.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"table": schema.StringAttribute{
-				Description: "The table definition.",
+				Description: "The table definition, sent to the controller as given with any structured attributes merged on top. Formatting, key order and defaults filled in by the controller do not cause a diff.",
 				Required:    true,
-				Sensitive:   true,
+				CustomType:  customtypes.TableConfigType{},
+				PlanModifiers: []planmodifier.String{
+					equivalentTableConfig{},
+				},
 			},
 			"table_type": schema.StringAttribute{
-				Description: "The table type.",
+				Description: "The table type. Changing this forces a new table to be created.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"segments_config": schema.SingleNestedAttribute{
 				Description: "The segments configuration for the table.",
@@ -90,7 +157,7 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Required:    true,
 					},
 					"time_column_name": schema.StringAttribute{
-						Description: "The time column name for the segments.",
+						Description: "The time column name for the segments. Changing this forces a new table to be created.",
 						Required:    true,
 					},
 					"retention_time_unit": schema.StringAttribute{
@@ -147,53 +214,7 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Description: "The create inverted index during segment generation for the table.",
 						Optional:    true,
 					},
-					"star_tree_index_configs": schema.ListNestedAttribute{
-						Description: "The star tree index configurations for the table.",
-						Optional:    true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"dimensions_split_order": schema.ListAttribute{
-									Description: "The dimensions split order for the star tree index.",
-									Optional:    true,
-									ElementType: types.StringType,
-								},
-								"skip_star_node_creation_for_dim_names": schema.ListAttribute{
-									Description: "The skip star node creation for dim names for the star tree index.",
-									Optional:    true,
-									ElementType: types.StringType,
-								},
-								"max_leaf_records": schema.Int64Attribute{
-									Description: "The max leaf records for the star tree index.",
-									Required:    true,
-								},
-								"function_column_pairs": schema.ListAttribute{
-									Description: "The function column pairs for the star tree index.",
-									Optional:    true,
-									ElementType: types.StringType,
-								},
-								"aggregation_configs": schema.ListNestedAttribute{
-									Description: "The aggregation configurations for the star tree index.",
-									Optional:    true,
-									NestedObject: schema.NestedAttributeObject{
-										Attributes: map[string]schema.Attribute{
-											"column_name": schema.StringAttribute{
-												Description: "The column name for the star tree index.",
-												Required:    true,
-											},
-											"aggregate_function": schema.StringAttribute{
-												Description: "The aggregate function for the star tree index.",
-												Required:    true,
-											},
-											"compression_codec": schema.StringAttribute{
-												Description: "The compression codec for the star tree index.",
-												Required:    true,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
+					"star_tree_index_configs": starTreeIndexConfigs,
 					"enable_dynamic_star_tree": schema.BoolAttribute{
 						Description: "The enable dynamic star tree for the table.",
 						Optional:    true,
@@ -233,11 +254,25 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Description: "The segment partition configuration for the table.",
 						Optional:    true,
 						Attributes: map[string]schema.Attribute{
-							"column_partition_map": schema.MapAttribute{
-								Description: "The column partition map for the segment partition config.",
+							"column_partition_map": schema.MapNestedAttribute{
+								Description: "The partition config of each partitioned column, keyed by column name.",
 								Optional:    true,
-								ElementType: types.MapType{
-									ElemType: types.StringType,
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"function_name": schema.StringAttribute{
+											Description: "The partition function, one of Modulo, Murmur, Murmur3, FNV, ByteArray, HashCode or BoundedColumnValue.",
+											Required:    true,
+										},
+										"num_partitions": schema.Int64Attribute{
+											Description: "The number of partitions.",
+											Required:    true,
+										},
+										"function_config": schema.MapAttribute{
+											Description: "Extra config passed to the partition function, e.g. seed for Murmur3.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+									},
 								},
 							},
 						},
@@ -274,6 +309,20 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Description: "The range index version for the table.",
 						Optional:    true,
 					},
+					"tier_overwrites": schema.MapNestedAttribute{
+						Description: "Index config overrides for the segments on a storage tier, keyed by the name of a tier in tier_configs.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"star_tree_index_configs": starTreeIndexConfigs,
+								"no_dictionary_columns": schema.ListAttribute{
+									Description: "The no dictionary columns for the segments on the tier.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+							},
+						},
+					},
 				},
 			},
 			"upsert_config": schema.SingleNestedAttribute{
@@ -281,7 +330,7 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"mode": schema.StringAttribute{
-						Description: "The upsert mode for the table.",
+						Description: "The upsert mode for the table. Changing this forces a new table to be created.",
 						Required:    true,
 					},
 					"partial_upsert_strategies": schema.MapAttribute{
@@ -312,10 +361,71 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						Optional:    true,
 						Attributes: map[string]schema.Attribute{
 							"stream_config_maps": schema.ListAttribute{
-								Description: "stream configuration",
+								Description: "stream configuration. Keys set here take precedence over the kafka block, which is merged into the first map.",
 								Optional:    true,
 								ElementType: types.MapType{ElemType: types.StringType},
 							},
+							"kafka": schema.SingleNestedAttribute{
+								Description: "kafka stream configuration, merged into the first entry of stream_config_maps",
+								Optional:    true,
+								Attributes: map[string]schema.Attribute{
+									"topic": schema.StringAttribute{
+										Description: "topic to consume, stream.kafka.topic.name",
+										Required:    true,
+									},
+									"broker_list": schema.StringAttribute{
+										Description: "comma separated bootstrap servers, stream.kafka.broker.list",
+										Required:    true,
+									},
+									"consumer_type": schema.StringAttribute{
+										Description: "consumer type, lowlevel or highlevel, stream.kafka.consumer.type",
+										Optional:    true,
+									},
+									"consumer_factory_class": schema.StringAttribute{
+										Description: "consumer factory class, stream.kafka.consumer.factory.class.name",
+										Optional:    true,
+									},
+									"decoder_class": schema.StringAttribute{
+										Description: "message decoder class, stream.kafka.decoder.class.name",
+										Required:    true,
+									},
+									"decoder_properties": schema.MapAttribute{
+										Description: "decoder properties, sent as stream.kafka.decoder.prop.<key>",
+										Optional:    true,
+										ElementType: types.StringType,
+									},
+									"offset_criteria": schema.StringAttribute{
+										Description: "where to start consuming new partitions, e.g. smallest or largest, stream.kafka.consumer.prop.auto.offset.reset",
+										Optional:    true,
+									},
+									"flush_threshold_rows": schema.Int64Attribute{
+										Description: "rows after which a consuming segment is committed, 0 to use flush_threshold_segment_size, realtime.segment.flush.threshold.rows",
+										Optional:    true,
+									},
+									"flush_threshold_time": schema.StringAttribute{
+										Description: "time after which a consuming segment is committed, e.g. 6h, realtime.segment.flush.threshold.time",
+										Optional:    true,
+									},
+									"flush_threshold_segment_size": schema.StringAttribute{
+										Description: "target size of committed segments, e.g. 200M, realtime.segment.flush.threshold.segment.size",
+										Optional:    true,
+									},
+									"security_protocol": schema.StringAttribute{
+										Description: "kafka security protocol, one of PLAINTEXT, SSL, SASL_PLAINTEXT or SASL_SSL",
+										Optional:    true,
+									},
+									"sasl_mechanism": schema.StringAttribute{
+										Description: "kafka SASL mechanism, e.g. PLAIN or SCRAM-SHA-512",
+										Optional:    true,
+									},
+									"security_properties": schema.MapAttribute{
+										Description: "Other kafka client security properties, keys starting with ssl. or sasl. Set passwords and sasl.jaas.config in stream_secrets instead.",
+										Optional:    true,
+										Sensitive:   true,
+										ElementType: types.StringType,
+									},
+								},
+							},
 						},
 					},
 					"transform_configs": schema.ListNestedAttribute{
@@ -334,6 +444,79 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							},
 						},
 					},
+					"batch_ingestion_config": schema.SingleNestedAttribute{
+						Description: "batch ingestion configuration",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"segment_ingestion_type": schema.StringAttribute{
+								Description: "segment ingestion type, APPEND or REFRESH",
+								Optional:    true,
+							},
+							"segment_ingestion_frequency": schema.StringAttribute{
+								Description: "segment ingestion frequency, DAILY or HOURLY",
+								Optional:    true,
+							},
+							"consistent_data_push": schema.BoolAttribute{
+								Description: "replace segments atomically when a batch job pushes new ones",
+								Optional:    true,
+							},
+							"batch_config_maps": schema.ListAttribute{
+								Description: "batch configuration, e.g. input directory, input format and output directory",
+								Optional:    true,
+								ElementType: types.MapType{ElemType: types.StringType},
+							},
+						},
+					},
+					"filter_config": schema.SingleNestedAttribute{
+						Description: "filter configuration",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"filter_function": schema.StringAttribute{
+								Description: "rows for which the filter function returns true are skipped",
+								Required:    true,
+							},
+						},
+					},
+					"complex_type_config": schema.SingleNestedAttribute{
+						Description: "complex type handling configuration",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"fields_to_unnest": schema.ListAttribute{
+								Description: "array fields to unnest into one row per element",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+							"delimiter": schema.StringAttribute{
+								Description: "delimiter used to join the names of flattened fields",
+								Optional:    true,
+							},
+							"collection_not_unnested_to_json": schema.StringAttribute{
+								Description: "collections converted to JSON strings when not unnested, one of NONE, NON_PRIMITIVE or ALL",
+								Optional:    true,
+							},
+							"prefixes_to_rename": schema.MapAttribute{
+								Description: "field name prefixes to rename, keyed by prefix",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+					"aggregation_configs": schema.ListNestedAttribute{
+						Description: "ingestion time aggregations for REALTIME tables",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"column_name": schema.StringAttribute{
+									Description: "column name",
+									Required:    true,
+								},
+								"aggregation_function": schema.StringAttribute{
+									Description: "aggregation function, e.g. SUM(price)",
+									Required:    true,
+								},
+							},
+						},
+					},
 				},
 			},
 			"tier_configs": schema.ListNestedAttribute{
@@ -346,28 +529,77 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							Required:    true,
 						},
 						"segment_selector_type": schema.StringAttribute{
-							Description: "segment selector type",
+							Description: "segment selector type, either time or fixed",
 							Required:    true,
 						},
 						"segment_age": schema.StringAttribute{
-							Description: "segment age",
-							Required:    true,
+							Description: "segment age, required by the time segment selector",
+							Optional:    true,
+						},
+						"segment_list": schema.ListAttribute{
+							Description: "names of the segments on the tier, required by the fixed segment selector",
+							Optional:    true,
+							ElementType: types.StringType,
 						},
 						"storage_type": schema.StringAttribute{
-							Description: "storage type",
+							Description: "storage type, only PINOT_SERVER is supported",
 							Required:    true,
 						},
 						"server_tag": schema.StringAttribute{
-							Description: "server tag",
+							Description: "tag of the servers that host the segments on the tier",
 							Required:    true,
 						},
 					},
 				},
 			},
+			"task_config": schema.MapAttribute{
+				Description: "The minion task configs, keyed by task type, e.g. RealtimeToOfflineSegmentsTask or MergeRollupTask. " +
+					"The schedule key of a task config takes a Quartz cron expression.",
+				Optional: true,
+				ElementType: types.MapType{
+					ElemType: types.StringType,
+				},
+			},
 			"is_dim_table": schema.BoolAttribute{
 				Description: "is dimension table",
 				Optional:    true,
 			},
+			"dimension_table_config": schema.SingleNestedAttribute{
+				Description: "The dimension table configuration. Dimension tables need primary key columns in their schema.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"disable_preload": schema.BoolAttribute{
+						Description: "Load rows on lookup instead of keeping the whole table in memory.",
+						Optional:    true,
+					},
+					"error_on_duplicate_primary_key": schema.BoolAttribute{
+						Description: "Fail loading the table when two rows share a primary key.",
+						Optional:    true,
+					},
+				},
+			},
+			"dedup_config": schema.SingleNestedAttribute{
+				Description: "The deduplication configuration for REALTIME tables. Deduplication needs primary key columns in the schema.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Drop rows whose primary key was already ingested.",
+						Required:    true,
+					},
+					"hash_function": schema.StringAttribute{
+						Description: "The hash function applied to primary keys, one of NONE, MD5 or MURMUR3.",
+						Optional:    true,
+					},
+					"metadata_ttl": schema.Float64Attribute{
+						Description: "How long primary keys are kept, in the unit of dedup_time_column.",
+						Optional:    true,
+					},
+					"dedup_time_column": schema.StringAttribute{
+						Description: "The time column metadata_ttl is measured against, defaults to the table time column.",
+						Optional:    true,
+					},
+				},
+			},
 			"metadata": schema.SingleNestedAttribute{
 				Description: "metadata for the table",
 				Optional:    true,
@@ -379,6 +611,159 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"instance_assignment_config_map": schema.MapNestedAttribute{
+				Description: "How instances are assigned to the table, keyed by instance partitions type: OFFLINE, CONSUMING, COMPLETED or a tier name.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tag_pool_config": schema.SingleNestedAttribute{
+							Description: "The instances to pick from.",
+							Required:    true,
+							Attributes: map[string]schema.Attribute{
+								"tag": schema.StringAttribute{
+									Description: "The tag of the instances, e.g. DefaultTenant_OFFLINE.",
+									Required:    true,
+								},
+								"pool_based": schema.BoolAttribute{
+									Description: "Assign instances by pool.",
+									Optional:    true,
+								},
+								"num_pools": schema.Int64Attribute{
+									Description: "The number of pools to use.",
+									Optional:    true,
+								},
+								"pools": schema.ListAttribute{
+									Description: "The pools to use.",
+									Optional:    true,
+									ElementType: types.Int64Type,
+								},
+							},
+						},
+						"replica_group_partition_config": schema.SingleNestedAttribute{
+							Description: "How the instances are split into replica groups and partitions.",
+							Optional:    true,
+							Attributes: map[string]schema.Attribute{
+								"replica_group_based": schema.BoolAttribute{
+									Description: "Split the instances into replica groups.",
+									Optional:    true,
+								},
+								"num_instances": schema.Int64Attribute{
+									Description: "The number of instances to use when not replica group based.",
+									Optional:    true,
+								},
+								"num_replica_groups": schema.Int64Attribute{
+									Description: "The number of replica groups, must match segments_config.replication.",
+									Optional:    true,
+								},
+								"num_instances_per_replica_group": schema.Int64Attribute{
+									Description: "The number of instances in each replica group.",
+									Optional:    true,
+								},
+								"num_partitions": schema.Int64Attribute{
+									Description: "The number of partitions.",
+									Optional:    true,
+								},
+								"num_instances_per_partition": schema.Int64Attribute{
+									Description: "The number of instances in each partition.",
+									Optional:    true,
+								},
+								"partition_column": schema.StringAttribute{
+									Description: "The column the segments are partitioned by.",
+									Optional:    true,
+								},
+								"minimize_data_movement": schema.BoolAttribute{
+									Description: "Keep instances in their replica group when the assignment changes.",
+									Optional:    true,
+								},
+							},
+						},
+						"constraint_config": schema.SingleNestedAttribute{
+							Description: "The constraints applied to the instances.",
+							Optional:    true,
+							Attributes: map[string]schema.Attribute{
+								"constraints": schema.ListAttribute{
+									Description: "The constraints.",
+									Optional:    true,
+									ElementType: types.StringType,
+								},
+							},
+						},
+						"partition_selector": schema.StringAttribute{
+							Description: "The partition selector, e.g. INSTANCE_REPLICA_GROUP_PARTITION_SELECTOR or FD_AWARE_INSTANCE_PARTITION_SELECTOR.",
+							Optional:    true,
+						},
+						"minimize_data_movement": schema.BoolAttribute{
+							Description: "Keep instances where they are when the assignment changes.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"segment_assignment_config_map": schema.MapNestedAttribute{
+				Description: "How segments are assigned to instances, keyed by instance partitions type: OFFLINE, CONSUMING, COMPLETED or a tier name.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"assignment_strategy": schema.StringAttribute{
+							Description: "The assignment strategy, one of balanced, replicaGroup or dimTable.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"instance_partitions_map": schema.MapAttribute{
+				Description: "Pre-built instance partitions to use, keyed by instance partitions type.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"routing": schema.SingleNestedAttribute{
+				Description: "The routing configuration for the table.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"segment_pruner_types": schema.ListAttribute{
+						Description: "The segment pruners the broker applies, any of partition, time and empty.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"instance_selector_type": schema.StringAttribute{
+						Description: "The instance selector, one of balanced, replicaGroup, strictReplicaGroup or multiStageReplicaGroup.",
+						Optional:    true,
+					},
+				},
+			},
+			"query": schema.SingleNestedAttribute{
+				Description: "The query configuration for the table.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"timeout_ms": schema.Int64Attribute{
+						Description: "The query timeout in milliseconds.",
+						Optional:    true,
+					},
+					"expression_override_map": schema.MapAttribute{
+						Description: "Expressions the broker rewrites before running a query, keyed by the expression to replace.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"max_query_response_size_bytes": schema.Int64Attribute{
+						Description: "The maximum size of a query response in bytes.",
+						Optional:    true,
+					},
+				},
+			},
+			"quota": schema.SingleNestedAttribute{
+				Description: "The quota configuration for the table.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"storage": schema.StringAttribute{
+						Description: "The storage quota, e.g. 10G or 500M.",
+						Optional:    true,
+					},
+					"max_queries_per_second": schema.Float64Attribute{
+						Description: "The maximum number of queries per second across all brokers.",
+						Optional:    true,
+					},
+				},
+			},
 			"field_config_list": schema.ListNestedAttribute{
 				Description: "field configurations for the table",
 				Optional:    true,
@@ -412,6 +797,15 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 								},
 							},
 						},
+						"compression_codec": schema.StringAttribute{
+							Description: "compression codec for raw values",
+							Optional:    true,
+						},
+						"properties": schema.MapAttribute{
+							Description: "free-form field properties",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
 						"indexes": schema.SingleNestedAttribute{
 							Description: "indexes",
 							Optional:    true,
@@ -426,33 +820,497 @@ func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 										},
 									},
 								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-
-	var plan models.TableResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	var table model.Table
-	err := json.Unmarshal([]byte(plan.Table.ValueString()), &table)
-	if err != nil {
-		resp.Diagnostics.AddError("Create Failed: Unable to unmarshal table from config", err.Error())
-		return
+								"json": schema.SingleNestedAttribute{
+									Description: "The JSON index, for querying nested fields of a JSON column with JSON_MATCH.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"max_levels": schema.Int64Attribute{
+											Description: "The maximum number of levels to flatten, -1 for no limit.",
+											Optional:    true,
+										},
+										"exclude_array": schema.BoolAttribute{
+											Description: "Skip indexing arrays.",
+											Optional:    true,
+										},
+										"disable_cross_array_unnest": schema.BoolAttribute{
+											Description: "Do not unnest across multiple arrays of the same document.",
+											Optional:    true,
+										},
+										"include_paths": schema.ListAttribute{
+											Description: "Only index these paths.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+										"exclude_paths": schema.ListAttribute{
+											Description: "Do not index these paths.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+										"exclude_fields": schema.ListAttribute{
+											Description: "Do not index fields with these names.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+										"index_paths": schema.ListAttribute{
+											Description: "Only index paths matching these patterns.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+										"max_value_length": schema.Int64Attribute{
+											Description: "Values longer than this are indexed as a placeholder.",
+											Optional:    true,
+										},
+									},
+								},
+								"text": schema.SingleNestedAttribute{
+									Description: "The text index, for full text search with TEXT_MATCH.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"fst_type": schema.StringAttribute{
+											Description: "The FST implementation used by the text index, LUCENE or NATIVE.",
+											Optional:    true,
+										},
+										"raw_value": schema.StringAttribute{
+											Description: "The value stored in place of documents that are too long to index.",
+											Optional:    true,
+										},
+										"query_cache": schema.BoolAttribute{
+											Description: "Cache text index query results.",
+											Optional:    true,
+										},
+										"use_and_for_multi_term_queries": schema.BoolAttribute{
+											Description: "Combine the terms of a multi term query with AND instead of OR.",
+											Optional:    true,
+										},
+										"stop_words_include": schema.ListAttribute{
+											Description: "Extra stop words to skip.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+										"stop_words_exclude": schema.ListAttribute{
+											Description: "Default stop words to index anyway.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+										"lucene_use_compound_file": schema.BoolAttribute{
+											Description: "Store the Lucene index as a compound file.",
+											Optional:    true,
+										},
+										"lucene_max_buffer_size_mb": schema.Int64Attribute{
+											Description: "The Lucene indexing buffer size in MB.",
+											Optional:    true,
+										},
+										"lucene_analyzer_class": schema.StringAttribute{
+											Description: "The Lucene analyzer class.",
+											Optional:    true,
+										},
+									},
+								},
+								"fst": schema.SingleNestedAttribute{
+									Description: "The FST index, for regex queries on dictionary encoded columns.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"type": schema.StringAttribute{
+											Description: "The FST implementation, LUCENE or NATIVE.",
+											Optional:    true,
+										},
+									},
+								},
+								"h3": schema.SingleNestedAttribute{
+									Description: "The H3 geospatial index.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"resolutions": schema.ListAttribute{
+											Description: "The H3 resolutions to index.",
+											Optional:    true,
+											ElementType: types.Int64Type,
+										},
+									},
+								},
+								"range": schema.SingleNestedAttribute{
+									Description: "The range index.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"version": schema.Int64Attribute{
+											Description: "The range index version.",
+											Optional:    true,
+										},
+									},
+								},
+								"bloom": schema.SingleNestedAttribute{
+									Description: "The bloom filter.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"fpp": schema.Float64Attribute{
+											Description: "The false positive probability, between 0 and 1.",
+											Optional:    true,
+										},
+										"max_size_in_bytes": schema.Int64Attribute{
+											Description: "The maximum size of the bloom filter.",
+											Optional:    true,
+										},
+										"load_on_heap": schema.BoolAttribute{
+											Description: "Load the bloom filter on heap.",
+											Optional:    true,
+										},
+									},
+								},
+								"forward": schema.SingleNestedAttribute{
+									Description: "The forward index.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"disabled": schema.BoolAttribute{
+											Description: "Disable the forward index. The column needs a dictionary and an inverted index.",
+											Optional:    true,
+										},
+										"compression_codec": schema.StringAttribute{
+											Description: "The compression codec for raw values, e.g. LZ4, SNAPPY, ZSTANDARD or PASS_THROUGH.",
+											Optional:    true,
+										},
+										"derive_num_docs_per_chunk": schema.BoolAttribute{
+											Description: "Derive the number of documents per chunk from the value size.",
+											Optional:    true,
+										},
+										"raw_index_writer_version": schema.Int64Attribute{
+											Description: "The raw index writer version.",
+											Optional:    true,
+										},
+									},
+								},
+								"vector": schema.SingleNestedAttribute{
+									Description: "The vector index, for similarity search with VECTOR_SIMILARITY.",
+									Optional:    true,
+									Attributes: map[string]schema.Attribute{
+										"vector_index_type": schema.StringAttribute{
+											Description: "The vector index type, e.g. HNSW.",
+											Optional:    true,
+										},
+										"vector_dimension": schema.Int64Attribute{
+											Description: "The number of dimensions of the vectors.",
+											Optional:    true,
+										},
+										"vector_distance_function": schema.StringAttribute{
+											Description: "The distance function, one of COSINE, EUCLIDEAN, INNER_PRODUCT or DOT_PRODUCT.",
+											Optional:    true,
+										},
+										"version": schema.Int64Attribute{
+											Description: "The vector index version.",
+											Optional:    true,
+										},
+										"properties": schema.MapAttribute{
+											Description: "Extra properties of the vector index.",
+											Optional:    true,
+											ElementType: types.StringType,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"stream_secrets": schema.MapAttribute{
+				Description: "Secret stream config values, e.g. sasl.jaas.config or ssl.keystore.password, keyed by stream config key. " +
+					"They are added to every stream config when the table is sent to the controller and left out of the table definition, " +
+					"effective_config_json and everything read back from the controller.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"detect_stream_secret_drift": schema.BoolAttribute{
+				Description: "Compare the SHA-256 hashes of the stream secrets on the controller with stream_secrets on read, and plan an update when they differ.",
+				Optional:    true,
+			},
+			"effective_config_json": schema.StringAttribute{
+				Description: "The table config sent to the controller, with the structured attributes merged into the table definition. " +
+					"Refreshed from the controller on read, so a plan shows the difference between the live config and the one that will be applied. " +
+					"Both are normalized like the table definition: empty values and controller defaults are left out and scalars are strings. " +
+					"Stream credentials are never included. " +
+					"Changing tableType, segmentsConfig.timeColumnName or upsertConfig.mode here forces a new table to be created.",
+				Computed: true,
+			},
+			"delete_schema": schema.BoolAttribute{
+				Description: "Delete the schema of the table, segmentsConfig.schemaName or else the table name, once the table has been deleted. The schema is kept while the table of the other type still exists.",
+				Optional:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *tableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var config models.TableResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	overriddenTableBytes, err := json.Marshal(override(&plan))
+	resp.Diagnostics.Append(validateStreamSecrets(ctx, &config)...)
+
+	if !config.TableType.IsUnknown() && !config.TableType.IsNull() && config.DedupConfig != nil && config.TableType.ValueString() != "REALTIME" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dedup_config"),
+			"Deduplication Requires a Realtime Table",
+			"Pinot only supports deduplication on REALTIME tables. Remove dedup_config or set table_type to REALTIME.",
+		)
+	}
+
+	if config.DedupConfig != nil {
+		resp.Diagnostics.Append(validateOneOf(path.Root("dedup_config").AtName("hash_function"), config.DedupConfig.HashFunction, hashFunctions)...)
+	}
+
+	if !config.TableType.IsUnknown() && !config.TableType.IsNull() && config.UpsertConfig != nil && config.TableType.ValueString() != "REALTIME" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("upsert_config"),
+			"Upsert Requires a Realtime Table",
+			"Pinot only supports upsert on REALTIME tables. Remove upsert_config or set table_type to REALTIME.",
+		)
+	}
+
+	resp.Diagnostics.Append(validateSegmentPartitionConfig(&config)...)
+	resp.Diagnostics.Append(validateFieldConfigList(&config)...)
+	resp.Diagnostics.Append(validateTaskConfig(ctx, &config)...)
+	resp.Diagnostics.Append(validateRoutingQueryQuota(ctx, &config)...)
+	resp.Diagnostics.Append(validateIngestionConfig(ctx, &config)...)
+	resp.Diagnostics.Append(validateTierConfigs(&config)...)
+
+	var table tableDefinition
+	if !config.Table.IsUnknown() && !config.Table.IsNull() {
+		err := json.Unmarshal([]byte(config.Table.ValueString()), &table)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("table"), "Invalid Table Definition", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(validateInstanceAssignment(&config, table.replication(&config))...)
+
+	// The controller keys a table by its name and type, so a mismatch here would update or replace the wrong table.
+	if table.TableName != "" && !config.TableName.IsUnknown() && table.TableName != config.TableName.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_name"),
+			"Table Name Mismatch",
+			fmt.Sprintf("table_name is %q but the table definition has tableName %q. Pinot cannot rename a table in place, so both must match.", config.TableName.ValueString(), table.TableName),
+		)
+	}
+
+	if table.TableType != "" && !config.TableType.IsUnknown() && !strings.EqualFold(table.TableType, config.TableType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("table_type"),
+			"Table Type Mismatch",
+			fmt.Sprintf("table_type is %q but the table definition has tableType %q. Pinot cannot change the type of a table in place, so both must match.", config.TableType.ValueString(), table.TableType),
+		)
+	}
+}
+
+func (r *tableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+
+	// nothing to compute when the table is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan models.TableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the framework only marks the effective config unknown when something changed, otherwise the live config in state is kept.
+	// Values that are only known after apply leave it unknown until then.
+	if !plan.EffectiveConfigJSON.IsUnknown() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	tableConfig, err := override(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Plan Failed: Unable to unmarshal table from config", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkPrimaryKeyColumns(plan.TableName.ValueString(), tableConfig)...)
+	resp.Diagnostics.Append(r.checkServerTags(tableConfig)...)
+
+	effectiveConfig, err := effectiveConfigJSON(tableConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Plan Failed: Unable to marshal table", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_config_json"), effectiveConfig)...)
+
+	// nothing to replace when the table is being created
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state models.TableResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || state.EffectiveConfigJSON.IsNull() {
+		return
+	}
+
+	// The fields can be set in the table definition or in a structured attribute, so they are compared in the
+	// merged config. The effective config is the one attribute that changes whenever any of them does.
+	var priorConfig map[string]any
+	err = json.Unmarshal([]byte(state.EffectiveConfigJSON.ValueString()), &priorConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Plan Failed: Unable to unmarshal effective config from state", err.Error())
+		return
+	}
+
+	if changes := immutableTableConfigChanges(priorConfig, tableConfig); len(changes) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Pinot cannot change %s in place, replacing table: %s", strings.Join(changes, ", "), plan.TableName))
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("effective_config_json"))
+	}
+}
+
+// checkServerTags warns when the server tag of a tier is not on any live, enabled server, the controller accepts the
+// table config but never moves segments to such a tier.
+func (r *tableResource) checkServerTags(tableConfig map[string]any) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	tierConfigs, _ := tableConfig["tierConfigs"].([]any)
+	if len(tierConfigs) == 0 {
+		return diags
+	}
+
+	instances, err := r.client.GetInstances()
+	if err != nil {
+		diags.AddWarning("Unable to Check Server Tags", fmt.Sprintf("Failed to list instances: %s", err))
+		return diags
+	}
+
+	liveInstances, err := getLiveInstances(r.client)
+	if err != nil {
+		diags.AddWarning("Unable to Check Server Tags", fmt.Sprintf("Failed to list live instances: %s", err))
+		return diags
+	}
+
+	// only live servers can host the segments of a tier
+	var serverNames []string
+	for _, instanceName := range instances.Instances {
+		if instanceType(instanceName) == "SERVER" && contains(liveInstances, instanceName) {
+			serverNames = append(serverNames, instanceName)
+		}
+	}
+
+	servers := make([]*pinotInstance, len(serverNames))
+	err = forEachConcurrently(len(serverNames), func(i int) error {
+		server, err := getInstance(r.client, serverNames[i])
+		if err != nil {
+			return fmt.Errorf("instance %s: %w", serverNames[i], err)
+		}
+		servers[i] = server
+		return nil
+	})
+	if err != nil {
+		diags.AddWarning("Unable to Check Server Tags", fmt.Sprintf("Failed to get instance: %s", err))
+		return diags
+	}
+
+	tags := map[string]bool{}
+	for _, server := range servers {
+		if !server.Enabled {
+			continue
+		}
+		for _, tag := range server.Tags {
+			tags[tag] = true
+		}
+	}
+
+	for _, value := range tierConfigs {
+		tierConfig, _ := value.(map[string]any)
+		serverTag, _ := tierConfig["serverTag"].(string)
+		if serverTag == "" || tags[serverTag] {
+			continue
+		}
+		diags.AddWarning(
+			"Server Tag Not Found",
+			fmt.Sprintf("Tier %v has server tag %q, but no live and enabled server carries it. Segments will not move to the tier until a server is tagged %q.",
+				tierConfig["name"], serverTag, serverTag),
+		)
+	}
+
+	return diags
+}
+
+// checkPrimaryKeyColumns warns when deduplication or a dimension table is enabled but the schema of the table has no
+// primary key columns. It only warns because the schema may gain them in the same apply, a schema that does not
+// exist yet is skipped for the same reason.
+func (r *tableResource) checkPrimaryKeyColumns(tableName string, tableConfig map[string]any) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	dedupEnabled := fmt.Sprint(lookup(tableConfig, "dedupConfig", "dedupEnabled")) == "true"
+	isDimTable := fmt.Sprint(tableConfig["isDimTable"]) == "true"
+	if !dedupEnabled && !isDimTable {
+		return diags
+	}
+
+	schemaName, ok := tableSchemaName(tableName, tableConfig)
+	if !ok {
+		return diags
+	}
+
+	tableSchema, err := r.client.GetSchema(schemaName)
+	if err != nil {
+		if !isNotFound(err) {
+			diags.AddWarning("Unable to Check Primary Key Columns", fmt.Sprintf("Failed to get schema %s: %s", schemaName, err))
+		}
+		return diags
+	}
+
+	if len(tableSchema.PrimaryKeyColumns) > 0 {
+		return diags
+	}
+
+	attributePath, feature := path.Root("dedup_config"), "Deduplication"
+	if isDimTable {
+		attributePath, feature = path.Root("is_dim_table"), "A dimension table"
+	}
+
+	diags.AddAttributeWarning(
+		attributePath,
+		"Schema Has No Primary Key Columns",
+		fmt.Sprintf("%s needs primary key columns, but schema %s declares none. The controller rejects the table unless primaryKeyColumns are added to the schema before it is applied.", feature, schemaName),
+	)
+
+	return diags
+}
+
+func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+
+	var plan models.TableResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tableConfig, err := override(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Create Failed: Unable to unmarshal table from config", err.Error())
+		return
+	}
+
+	effectiveConfig, err := effectiveConfigJSON(tableConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Create Failed: Unable to marshal table", err.Error())
+		return
+	}
+	plan.EffectiveConfigJSON = types.StringValue(effectiveConfig)
+
+	// secrets are only added to the request, never to the effective config
+	overriddenTableBytes, err := json.Marshal(withStreamSecrets(ctx, tableConfig, plan.StreamSecrets))
 	if err != nil {
 		resp.Diagnostics.AddError("Create Failed: Unable to marshal table", err.Error())
 		return
@@ -480,26 +1338,62 @@ func (r *tableResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	tableResponse, err := r.client.GetTable(state.TableName.ValueString())
+	liveConfig, err := getTableConfig(r.client, state.TableName.ValueString(), state.TableType.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get table", err.Error())
 		return
 	}
 
-	tflog.Info(ctx, "here\n")
-
-	var table model.Table
+	// the table was deleted outside of terraform
+	if liveConfig == nil {
+		tflog.Info(ctx, fmt.Sprintf("Table not found, removing from state: %s", state.TableName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	// if table.OFFLINE is not nil, set the state to populated data
-	if tableResponse.OFFLINE.TableName != "" {
-		table = tableResponse.OFFLINE
-	} else {
-		table = tableResponse.REALTIME
+	// secret values never go into state, only a comparison of their hashes when asked for
+	if state.DetectStreamSecretDrift.ValueBool() {
+		state.StreamSecrets = streamSecretsDrift(ctx, liveConfig, state.StreamSecrets)
 	}
+	withoutStreamSecrets(liveConfig, state.StreamSecrets)
 
 	tflog.Info(ctx, "setting state\n")
 
-	converter.SetStateFromTable(ctx, &state, &table)
+	prior := state
+	err = converter.SetStateFromTableConfig(ctx, &state, liveConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal table", err.Error())
+		return
+	}
+	keepConfiguredAttributes(&prior, &state)
+
+	// Credentials only stay in the sensitive structured attributes they are configured in, the table definition
+	// and the effective config are not sensitive.
+	withoutStreamCredentials(liveConfig)
+
+	// Keep the table definition from config while the controller still holds what it produces,
+	// otherwise store the live config so changes to fields without a structured attribute show up as drift.
+	expectedConfig, err := override(&prior)
+	if err == nil {
+		// a secret also set in the table definition is only compared through stream_secrets
+		withoutStreamSecrets(expectedConfig, prior.StreamSecrets)
+		withoutStreamCredentials(expectedConfig)
+	}
+	if err != nil || !tableConfigsEqual(expectedConfig, liveConfig) {
+		liveConfigBytes, err := json.MarshalIndent(liveConfig, "", "  ")
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to marshal table", err.Error())
+			return
+		}
+		state.Table = customtypes.NewTableConfigValue(string(liveConfigBytes))
+	}
+
+	effectiveConfig, err := effectiveConfigJSON(liveConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to marshal table", err.Error())
+		return
+	}
+	state.EffectiveConfigJSON = types.StringValue(effectiveConfig)
 
 	// set state to populated data
 	diags = resp.State.Set(ctx, &state)
@@ -521,7 +1415,21 @@ func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	tflog.Info(ctx, fmt.Sprintf("Overriding table config: %s", plan.TableName))
 
-	overriddenTableBytes, err := json.Marshal(override(&plan))
+	tableConfig, err := override(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Update Failed: Unable to unmarshal table from config", err.Error())
+		return
+	}
+
+	effectiveConfig, err := effectiveConfigJSON(tableConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Update Failed: Unable to marshal table", err.Error())
+		return
+	}
+	plan.EffectiveConfigJSON = types.StringValue(effectiveConfig)
+
+	// secrets are only added to the request, never to the effective config
+	overriddenTableBytes, err := json.Marshal(withStreamSecrets(ctx, tableConfig, plan.StreamSecrets))
 	if err != nil {
 		resp.Diagnostics.AddError("Update Failed: Unable to marshal table", err.Error())
 		return
@@ -562,14 +1470,45 @@ func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTableDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	tableName := state.TableName.ValueString()
+	tableType := state.TableType.ValueString()
+
 	tflog.Info(ctx, fmt.Sprintf("Deleting table: %s", state.TableName))
 
-	_, err := r.client.DeleteTable(state.TableName.ValueString())
+	// without a type the controller deletes both halves of a hybrid table
+	var deleteResp model.UserActionResponse
+	err := r.client.DeleteObject(fmt.Sprintf("/tables/%s", tableName), map[string]string{"type": strings.ToLower(tableType)}, &deleteResp)
 	if err != nil {
 		resp.Diagnostics.AddError("Delete Failed: Unable to delete table", err.Error())
 		return
 	}
 
+	tflog.Info(ctx, fmt.Sprintf("Waiting for table to be removed: %s", state.TableName))
+
+	err = waitFor(ctx, func() (bool, error) {
+		return r.tableDeleted(tableName, tableType)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Delete Failed: Table was not removed", err.Error())
+		return
+	}
+
+	if state.DeleteSchema.ValueBool() {
+		resp.Diagnostics.Append(r.deleteSchema(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// set state to populated data
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -578,97 +1517,273 @@ func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
-func override(plan *models.TableResourceModel) *model.Table {
+// deleteSchema deletes the schema of a deleted table, unless the table of the other type still uses it.
+func (r *tableResource) deleteSchema(ctx context.Context, state *models.TableResourceModel) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	tableName := state.TableName.ValueString()
+
+	otherTableType := "REALTIME"
+	if state.TableType.ValueString() == "REALTIME" {
+		otherTableType = "OFFLINE"
+	}
+
+	otherTableConfig, err := getTableConfig(r.client, tableName, otherTableType)
+	if err != nil {
+		diags.AddError("Delete Failed: Unable to get table", err.Error())
+		return diags
+	}
+	if otherTableConfig != nil {
+		diags.AddWarning(
+			"Schema Not Deleted",
+			fmt.Sprintf("The %s table %s still exists and uses the schema, so it was not deleted.", otherTableType, tableName),
+		)
+		return diags
+	}
+
+	tableConfig, err := override(state)
+	if err != nil {
+		diags.AddError("Delete Failed: Unable to unmarshal table from config", err.Error())
+		return diags
+	}
+
+	schemaName, ok := tableSchemaName(tableName, tableConfig)
+	if !ok {
+		diags.AddWarning("Schema Not Deleted", "The table definition has an invalid segmentsConfig.schemaName, so the schema was not deleted.")
+		return diags
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting schema: %s", schemaName))
+
+	_, err = r.client.DeleteSchema(schemaName)
+	if err != nil && !isNotFound(err) {
+		diags.AddError("Delete Failed: Unable to delete schema", err.Error())
+		return diags
+	}
+
+	err = waitFor(ctx, func() (bool, error) {
+		_, err := r.client.GetSchema(schemaName)
+		if isNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		diags.AddError("Delete Failed: Schema was not removed", err.Error())
+	}
+
+	return diags
+}
+
+// tableSchemaName returns the name of the schema the controller uses for a table: segmentsConfig.schemaName
+// when set, otherwise the table name. It reports false when schemaName is set but not a usable name.
+func tableSchemaName(tableName string, tableConfig map[string]any) (string, bool) {
+
+	configuredSchemaName := lookup(tableConfig, "segmentsConfig", "schemaName")
+	if configuredSchemaName == nil {
+		return tableName, true
+	}
+
+	schemaName, ok := configuredSchemaName.(string)
+	if !ok || schemaName == "" {
+		return "", false
+	}
+
+	return schemaName, true
+}
+
+// tableDeleted reports whether both the table config and the ideal state of the table are gone.
+// The controller removes the config first and drops the ideal state once all segments are deleted.
+func (r *tableResource) tableDeleted(tableName string, tableType string) (bool, error) {
+
+	tableResp, err := r.client.GetTable(tableName)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+
+	if err == nil {
+		if tableType == "REALTIME" && tableResp.REALTIME.TableName != "" {
+			return false, nil
+		}
+		if tableType != "REALTIME" && tableResp.OFFLINE.TableName != "" {
+			return false, nil
+		}
+	}
+
+	var idealState map[string]map[string]map[string]string
+	err = r.client.FetchData(fmt.Sprintf("/tables/%s/idealstate?tableType=%s", tableName, tableType), &idealState)
+	if isNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, exists := idealState[tableType]
+	return !exists || idealState[tableType] == nil, nil
+}
+
+// override builds the table config sent to the controller. The table definition is sent as given,
+// with every structured attribute that is set deep-merged on top of it.
+func override(plan *models.TableResourceModel) (map[string]any, error) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	table := model.Table{
-		TableName:        plan.TableName.ValueString(),
-		TableType:        plan.TableType.ValueString(),
-		Tenants:          overrideTenantsConfig(plan),
-		SegmentsConfig:   overrideSegmentsConfig(plan),
-		TableIndexConfig: overrideTableConfigs(ctx, plan),
-		IsDimTable:       plan.IsDimTable.ValueBool(),
-		IngestionConfig:  overrideIngestionConfig(plan),
+	tableConfig := map[string]any{}
+	err := json.Unmarshal([]byte(plan.Table.ValueString()), &tableConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]any{
+		"tableName": plan.TableName.ValueString(),
+		"tableType": plan.TableType.ValueString(),
+	}
+
+	if plan.SegmentsConfig != nil {
+		overrides["segmentsConfig"] = overrideSegmentsConfig(plan)
+	}
+
+	if plan.TenantsConfig != nil {
+		overrides["tenants"] = overrideTenantsConfig(ctx, plan)
+	}
+
+	if plan.TableIndexConfig != nil {
+		overrides["tableIndexConfig"] = overrideTableConfigs(ctx, plan)
+	}
+
+	if plan.IngestionConfig != nil {
+		overrides["ingestionConfig"] = overrideIngestionConfig(ctx, plan)
+	}
+
+	setValue(ctx, overrides, "isDimTable", plan.IsDimTable)
+
+	if !plan.TaskConfig.IsNull() && !plan.TaskConfig.IsUnknown() {
+		overrides["task"] = overrideTaskConfig(ctx, plan)
+	}
+
+	if plan.DimensionTableConfig != nil {
+		dimensionTableConfig := map[string]any{}
+		setValue(ctx, dimensionTableConfig, "disablePreload", plan.DimensionTableConfig.DisablePreload)
+		setValue(ctx, dimensionTableConfig, "errorOnDuplicatePrimaryKey", plan.DimensionTableConfig.ErrorOnDuplicatePrimaryKey)
+		overrides["dimensionTableConfig"] = dimensionTableConfig
+	}
+
+	if plan.DedupConfig != nil {
+		dedupConfig := map[string]any{}
+		setValue(ctx, dedupConfig, "dedupEnabled", plan.DedupConfig.Enabled)
+		setValue(ctx, dedupConfig, "hashFunction", plan.DedupConfig.HashFunction)
+		setValue(ctx, dedupConfig, "metadataTTL", plan.DedupConfig.MetadataTTL)
+		setValue(ctx, dedupConfig, "dedupTimeColumn", plan.DedupConfig.DedupTimeColumn)
+		overrides["dedupConfig"] = dedupConfig
 	}
 
 	if plan.Metadata != nil {
-		table.Metadata = overrideMetadata(plan)
+		overrides["metadata"] = overrideMetadata(plan)
+	}
+
+	if plan.InstanceAssignmentConfigMap != nil {
+		overrides["instanceAssignmentConfigMap"] = overrideInstanceAssignmentConfigMap(ctx, plan)
+	}
+
+	if plan.SegmentAssignmentConfigMap != nil {
+		overrides["segmentAssignmentConfigMap"] = overrideSegmentAssignmentConfigMap(ctx, plan)
+	}
+
+	setValue(ctx, overrides, "instancePartitionsMap", plan.InstancePartitionsMap)
+
+	if plan.Routing != nil {
+		overrides["routing"] = overrideRoutingConfig(ctx, plan)
+	}
+
+	if plan.Query != nil {
+		overrides["query"] = overrideQueryConfig(ctx, plan)
+	}
+
+	if plan.Quota != nil {
+		overrides["quota"] = overrideQuotaConfig(ctx, plan)
 	}
 
 	if plan.TierConfigs != nil {
-		table.TierConfigs = overrideTierConfigs(plan)
+		overrides["tierConfigs"] = overrideTierConfigs(ctx, plan)
 	}
 
 	if plan.FieldConfigList != nil {
-		table.FieldConfigList = overrideFieldConfigList(plan)
+		overrides["fieldConfigList"] = overrideFieldConfigList(ctx, plan)
+	}
 
+	if plan.UpsertConfig != nil {
+		overrides["upsertConfig"] = overrideUpsertConfig(ctx, plan)
 	}
 
-	return &table
+	return mergeTableConfig(tableConfig, overrides)
 }
 
-func overrideTableConfigs(ctx context.Context, plan *models.TableResourceModel) model.TableIndexConfig {
+func overrideTableConfigs(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	tableConfig := map[string]any{}
+
+	setValue(ctx, tableConfig, "createInvertedIndexDuringSegmentGeneration", plan.TableIndexConfig.CreateInvertedIndexDuringSegmentGeneration)
+	setValue(ctx, tableConfig, "sortedColumn", plan.TableIndexConfig.SortedColumn)
+	setValue(ctx, tableConfig, "enableDefaultStarTree", plan.TableIndexConfig.EnableDefaultStarTree)
+	setValue(ctx, tableConfig, "enableDynamicStarTreeCreation", plan.TableIndexConfig.EnableDynamicStarTree)
+	setValue(ctx, tableConfig, "loadMode", plan.TableIndexConfig.LoadMode)
+	setValue(ctx, tableConfig, "columnMinMaxValueGeneratorMode", plan.TableIndexConfig.ColumnMinMaxValueGeneratorMode)
+	setValue(ctx, tableConfig, "nullHandlingEnabled", plan.TableIndexConfig.NullHandlingEnabled)
+	setValue(ctx, tableConfig, "aggregateMetrics", plan.TableIndexConfig.AggregateMetrics)
+	setValue(ctx, tableConfig, "optimizeDictionary", plan.TableIndexConfig.OptimizeDictionary)
+	setValue(ctx, tableConfig, "optimizeDictionaryForMetrics", plan.TableIndexConfig.OptimizeDictionaryForMetrics)
+	setValue(ctx, tableConfig, "noDictionarySizeRatioThreshold", plan.TableIndexConfig.NoDictionarySizeRatioThreshold)
+	setValue(ctx, tableConfig, "segmentNameGeneratorType", plan.TableIndexConfig.SegmentNameGeneratorType)
+	setValue(ctx, tableConfig, "rangeIndexColumns", plan.TableIndexConfig.RangeIndexColumns)
+	setValue(ctx, tableConfig, "noDictionaryColumns", plan.TableIndexConfig.NoDictionaryColumns)
+	setValue(ctx, tableConfig, "rangeIndexVersion", plan.TableIndexConfig.RangeIndexVersion)
+	setValue(ctx, tableConfig, "onHeapDictionaryColumns", plan.TableIndexConfig.OnHeapDictionaryColumns)
+	setValue(ctx, tableConfig, "varLengthDictionaryColumns", plan.TableIndexConfig.VarLengthDictionaryColumns)
+	setValue(ctx, tableConfig, "bloomFilterColumns", plan.TableIndexConfig.BloomFilterColumns)
+
+	if plan.TableIndexConfig.StarTreeIndexConfigs != nil {
+		tableConfig["starTreeIndexConfigs"] = overrideStarTreeConfigs(ctx, plan.TableIndexConfig.StarTreeIndexConfigs)
+	}
+
+	if plan.TableIndexConfig.TierOverwrites != nil {
+		tableConfig["tierOverwrites"] = overrideTierOverwrites(ctx, plan)
+	}
 
-	tableConfig := model.TableIndexConfig{
-		CreateInvertedIndexDuringSegmentGeneration: plan.TableIndexConfig.CreateInvertedIndexDuringSegmentGeneration.ValueBool(),
-		SortedColumn:                   toStringList(ctx, plan.TableIndexConfig.SortedColumn),
-		StarTreeIndexConfigs:           overrideStarTreeConfigs(ctx, plan),
-		EnableDefaultStarTree:          plan.TableIndexConfig.EnableDefaultStarTree.ValueBool(),
-		EnableDynamicStarTreeCreation:  plan.TableIndexConfig.EnableDynamicStarTree.ValueBool(),
-		LoadMode:                       plan.TableIndexConfig.LoadMode.ValueString(),
-		ColumnMinMaxValueGeneratorMode: plan.TableIndexConfig.ColumnMinMaxValueGeneratorMode.ValueString(),
-		NullHandlingEnabled:            plan.TableIndexConfig.NullHandlingEnabled.ValueBool(),
-		AggregateMetrics:               plan.TableIndexConfig.AggregateMetrics.ValueBool(),
-		OptimizeDictionary:             plan.TableIndexConfig.OptimizeDictionary.ValueBool(),
-		OptimizeDictionaryForMetrics:   plan.TableIndexConfig.OptimizeDictionaryForMetrics.ValueBool(),
-		NoDictionarySizeRatioThreshold: plan.TableIndexConfig.NoDictionarySizeRatioThreshold.ValueFloat64(),
-		SegmentNameGeneratorType:       plan.TableIndexConfig.SegmentNameGeneratorType.ValueString(),
-		SegmentPartitionConfig:         overrideSegmentPartitionConfig(plan),
-		RangeIndexColumns:              toStringList(ctx, plan.TableIndexConfig.RangeIndexColumns),
-		NoDictionaryColumns:            toStringList(ctx, plan.TableIndexConfig.NoDictionaryColumns),
-		RangeIndexVersion:              int(plan.TableIndexConfig.RangeIndexVersion.ValueInt64()),
-		OnHeapDictionaryColumns:        toStringList(ctx, plan.TableIndexConfig.OnHeapDictionaryColumns),
-		VarLengthDictionaryColumns:     toStringList(ctx, plan.TableIndexConfig.VarLengthDictionaryColumns),
-		BloomFilterColumns:             toStringList(ctx, plan.TableIndexConfig.BloomFilterColumns),
+	if plan.TableIndexConfig.SegmentPartitionConfig != nil {
+		tableConfig["segmentPartitionConfig"] = overrideSegmentPartitionConfig(ctx, plan)
 	}
 
 	return tableConfig
 
 }
 
-func overrideSegmentPartitionConfig(plan *models.TableResourceModel) *model.SegmentPartitionConfig {
-
-	if plan.TableIndexConfig.SegmentPartitionConfig == nil {
-		return nil
-	}
+func overrideSegmentPartitionConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
 
-	columnPartitionMap := make(map[string]model.ColumnPartitionMapConfig, 1)
-	for key, value := range plan.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap {
+	columnPartitionMap := make(map[string]any, len(plan.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap))
+	for column, partitionConfig := range plan.TableIndexConfig.SegmentPartitionConfig.ColumnPartitionMap {
 
-		numPartitions, err := strconv.Atoi(value["numPartitions"])
-		if err != nil {
-			log.Panic(err)
-		}
+		columnPartitionConfig := map[string]any{}
+		setValue(ctx, columnPartitionConfig, "functionName", partitionConfig.FunctionName)
+		setValue(ctx, columnPartitionConfig, "numPartitions", partitionConfig.NumPartitions)
+		setValue(ctx, columnPartitionConfig, "functionConfig", partitionConfig.FunctionConfig)
 
-		columnPartitionMap[key] = model.ColumnPartitionMapConfig{
-			FunctionName: value["functionName"],
-			// convert to int
-			NumPartitions: numPartitions,
-		}
+		columnPartitionMap[column] = columnPartitionConfig
 	}
-	return &model.SegmentPartitionConfig{ColumnPartitionMap: columnPartitionMap}
+
+	return map[string]any{"columnPartitionMap": columnPartitionMap}
 }
 
-func overrideStarTreeConfigs(ctx context.Context, plan *models.TableResourceModel) []*model.StarTreeIndexConfig {
+func overrideStarTreeConfigs(ctx context.Context, starTreeIndexConfigs []*models.StarTreeIndexConfigs) []*model.StarTreeIndexConfig {
 
-	if plan.TableIndexConfig.StarTreeIndexConfigs == nil {
+	if starTreeIndexConfigs == nil {
 		return nil
 	}
 
 	var starTreeConfigs []*model.StarTreeIndexConfig
-	for _, starConfig := range plan.TableIndexConfig.StarTreeIndexConfigs {
+	for _, starConfig := range starTreeIndexConfigs {
 		starTreeConfigs = append(starTreeConfigs, &model.StarTreeIndexConfig{
 			MaxLeafRecords:                    int(starConfig.MaxLeafRecords.ValueInt64()),
 			DimensionsSplitOrder:              toStringList(ctx, starConfig.DimensionsSplitOrder),
@@ -679,52 +1794,173 @@ func overrideStarTreeConfigs(ctx context.Context, plan *models.TableResourceMode
 	return starTreeConfigs
 }
 
-func overrideSegmentsConfig(plan *models.TableResourceModel) model.TableSegmentsConfig {
+func overrideTierOverwrites(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	tierOverwrites := make(map[string]any, len(plan.TableIndexConfig.TierOverwrites))
+	for tier, tierOverwrite := range plan.TableIndexConfig.TierOverwrites {
+
+		overwrite := map[string]any{}
+		setValue(ctx, overwrite, "noDictionaryColumns", tierOverwrite.NoDictionaryColumns)
+		if tierOverwrite.StarTreeIndexConfigs != nil {
+			overwrite["starTreeIndexConfigs"] = overrideStarTreeConfigs(ctx, tierOverwrite.StarTreeIndexConfigs)
+		}
+
+		tierOverwrites[tier] = overwrite
+	}
+
+	return tierOverwrites
+}
+
+func overrideSegmentsConfig(plan *models.TableResourceModel) map[string]any {
+
+	segmentsConfig := map[string]any{
+		"timeType":       plan.SegmentsConfig.TimeType.ValueString(),
+		"replication":    plan.SegmentsConfig.Replication.ValueString(),
+		"timeColumnName": plan.SegmentsConfig.TimeColumnName.ValueString(),
+	}
 
-	segmentsConfig := model.TableSegmentsConfig{
-		TimeType:           plan.SegmentsConfig.TimeType.ValueString(),
-		Replication:        plan.SegmentsConfig.Replication.ValueString(),
-		TimeColumnName:     plan.SegmentsConfig.TimeColumnName.ValueString(),
-		RetentionTimeUnit:  plan.SegmentsConfig.RetentionTimeUnit.ValueString(),
-		RetentionTimeValue: plan.SegmentsConfig.RetentionTimeValue.ValueString(),
+	if plan.SegmentsConfig.RetentionTimeUnit.ValueString() != "" {
+		segmentsConfig["retentionTimeUnit"] = plan.SegmentsConfig.RetentionTimeUnit.ValueString()
+	}
+
+	if plan.SegmentsConfig.RetentionTimeValue.ValueString() != "" {
+		segmentsConfig["retentionTimeValue"] = plan.SegmentsConfig.RetentionTimeValue.ValueString()
 	}
 
 	if plan.SegmentsConfig.ReplicasPerPartition.ValueString() != "" {
-		segmentsConfig.ReplicasPerPartition = plan.SegmentsConfig.ReplicasPerPartition.ValueString()
+		segmentsConfig["replicasPerPartition"] = plan.SegmentsConfig.ReplicasPerPartition.ValueString()
 	}
 
 	if plan.SegmentsConfig.DeletedSegmentsRetentionPeriod.ValueString() != "" {
-		segmentsConfig.DeletedSegmentsRetentionPeriod = plan.SegmentsConfig.DeletedSegmentsRetentionPeriod.ValueString()
+		segmentsConfig["deletedSegmentsRetentionPeriod"] = plan.SegmentsConfig.DeletedSegmentsRetentionPeriod.ValueString()
 	}
 
 	return segmentsConfig
 
 }
 
-func overrideTenantsConfig(plan *models.TableResourceModel) model.TableTenant {
-	return model.TableTenant{
-		Broker: plan.TenantsConfig.Broker.ValueString(),
-		Server: plan.TenantsConfig.Server.ValueString(),
+func overrideTenantsConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	tenants := map[string]any{}
+
+	setValue(ctx, tenants, "broker", plan.TenantsConfig.Broker)
+	setValue(ctx, tenants, "server", plan.TenantsConfig.Server)
+	setValue(ctx, tenants, "tagOverrideConfig", plan.TenantsConfig.TagOverrideConfig)
+
+	return tenants
+}
+
+func overrideTierConfigs(ctx context.Context, plan *models.TableResourceModel) []map[string]any {
+
+	var tierConfigs []map[string]any
+	for _, tierConfig := range plan.TierConfigs {
+
+		config := map[string]any{}
+		setValue(ctx, config, "name", tierConfig.Name)
+		setValue(ctx, config, "segmentSelectorType", tierConfig.SegmentSelectorType)
+		setValue(ctx, config, "segmentAge", tierConfig.SegmentAge)
+		setValue(ctx, config, "segmentList", tierConfig.SegmentList)
+		setValue(ctx, config, "storageType", tierConfig.StorageType)
+		setValue(ctx, config, "serverTag", tierConfig.ServerTag)
+
+		tierConfigs = append(tierConfigs, config)
 	}
+	return tierConfigs
 }
 
-func overrideTierConfigs(plan *models.TableResourceModel) []*model.TierConfig {
+func overrideTaskConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
 
-	if plan.TierConfigs == nil {
-		return nil
+	var taskTypeConfigsMap map[string]map[string]string
+	plan.TaskConfig.ElementsAs(ctx, &taskTypeConfigsMap, false)
+
+	return map[string]any{"taskTypeConfigsMap": taskTypeConfigsMap}
+}
+
+func overrideInstanceAssignmentConfigMap(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	instanceAssignmentConfigMap := map[string]any{}
+	for instancePartitionsType, assignmentConfig := range plan.InstanceAssignmentConfigMap {
+
+		instanceAssignmentConfig := map[string]any{}
+		setValue(ctx, instanceAssignmentConfig, "partitionSelector", assignmentConfig.PartitionSelector)
+		setValue(ctx, instanceAssignmentConfig, "minimizeDataMovement", assignmentConfig.MinimizeDataMovement)
+
+		if assignmentConfig.TagPoolConfig != nil {
+			tagPoolConfig := map[string]any{}
+			setValue(ctx, tagPoolConfig, "tag", assignmentConfig.TagPoolConfig.Tag)
+			setValue(ctx, tagPoolConfig, "poolBased", assignmentConfig.TagPoolConfig.PoolBased)
+			setValue(ctx, tagPoolConfig, "numPools", assignmentConfig.TagPoolConfig.NumPools)
+			setValue(ctx, tagPoolConfig, "pools", assignmentConfig.TagPoolConfig.Pools)
+			instanceAssignmentConfig["tagPoolConfig"] = tagPoolConfig
+		}
+
+		if assignmentConfig.ReplicaGroupPartitionConfig != nil {
+			partitionConfig := assignmentConfig.ReplicaGroupPartitionConfig
+			replicaGroupPartitionConfig := map[string]any{}
+			setValue(ctx, replicaGroupPartitionConfig, "replicaGroupBased", partitionConfig.ReplicaGroupBased)
+			setValue(ctx, replicaGroupPartitionConfig, "numInstances", partitionConfig.NumInstances)
+			setValue(ctx, replicaGroupPartitionConfig, "numReplicaGroups", partitionConfig.NumReplicaGroups)
+			setValue(ctx, replicaGroupPartitionConfig, "numInstancesPerReplicaGroup", partitionConfig.NumInstancesPerReplicaGroup)
+			setValue(ctx, replicaGroupPartitionConfig, "numPartitions", partitionConfig.NumPartitions)
+			setValue(ctx, replicaGroupPartitionConfig, "numInstancesPerPartition", partitionConfig.NumInstancesPerPartition)
+			setValue(ctx, replicaGroupPartitionConfig, "partitionColumn", partitionConfig.PartitionColumn)
+			setValue(ctx, replicaGroupPartitionConfig, "minimizeDataMovement", partitionConfig.MinimizeDataMovement)
+			instanceAssignmentConfig["replicaGroupPartitionConfig"] = replicaGroupPartitionConfig
+		}
+
+		if assignmentConfig.ConstraintConfig != nil {
+			constraintConfig := map[string]any{}
+			setValue(ctx, constraintConfig, "constraints", assignmentConfig.ConstraintConfig.Constraints)
+			instanceAssignmentConfig["constraintConfig"] = constraintConfig
+		}
+
+		instanceAssignmentConfigMap[instancePartitionsType] = instanceAssignmentConfig
 	}
 
-	var tierConfigs []*model.TierConfig
-	for _, tierConfig := range plan.TierConfigs {
-		tierConfigs = append(tierConfigs, &model.TierConfig{
-			Name:                tierConfig.Name.ValueString(),
-			SegmentSelectorType: tierConfig.SegmentSelectorType.ValueString(),
-			SegmentAge:          tierConfig.SegmentAge.ValueString(),
-			StorageType:         tierConfig.StorageType.ValueString(),
-			ServerTag:           tierConfig.ServerTag.ValueString(),
-		})
+	return instanceAssignmentConfigMap
+}
+
+func overrideSegmentAssignmentConfigMap(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	segmentAssignmentConfigMap := map[string]any{}
+	for instancePartitionsType, assignmentConfig := range plan.SegmentAssignmentConfigMap {
+		segmentAssignmentConfig := map[string]any{}
+		setValue(ctx, segmentAssignmentConfig, "assignmentStrategy", assignmentConfig.AssignmentStrategy)
+		segmentAssignmentConfigMap[instancePartitionsType] = segmentAssignmentConfig
 	}
-	return tierConfigs
+
+	return segmentAssignmentConfigMap
+}
+
+func overrideRoutingConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	routing := map[string]any{}
+
+	setValue(ctx, routing, "segmentPrunerTypes", plan.Routing.SegmentPrunerTypes)
+	setValue(ctx, routing, "instanceSelectorType", plan.Routing.InstanceSelectorType)
+
+	return routing
+}
+
+func overrideQueryConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	query := map[string]any{}
+
+	setValue(ctx, query, "timeoutMs", plan.Query.TimeoutMs)
+	setValue(ctx, query, "expressionOverrideMap", plan.Query.ExpressionOverrideMap)
+	setValue(ctx, query, "maxQueryResponseSizeBytes", plan.Query.MaxQueryResponseSizeBytes)
+
+	return query
+}
+
+func overrideQuotaConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	quota := map[string]any{}
+
+	setValue(ctx, quota, "storage", plan.Quota.Storage)
+	setValue(ctx, quota, "maxQueriesPerSecond", plan.Quota.MaxQueriesPerSecond)
+
+	return quota
 }
 
 func overrideMetadata(plan *models.TableResourceModel) *model.TableMetadata {
@@ -738,19 +1974,37 @@ func overrideMetadata(plan *models.TableResourceModel) *model.TableMetadata {
 	}
 }
 
-func overrideIngestionConfig(plan *models.TableResourceModel) *model.TableIngestionConfig {
+func overrideUpsertConfig(ctx context.Context, plan *models.TableResourceModel) *model.UpsertConfig {
 
-	if plan.IngestionConfig == nil {
+	if plan.UpsertConfig == nil {
 		return nil
 	}
 
-	ingestionConfig := model.TableIngestionConfig{
-		SegmentTimeValueCheck: plan.IngestionConfig.SegmentTimeValueCheck.ValueBool(),
-		RowTimeValueCheck:     plan.IngestionConfig.RowTimeValueCheck.ValueBool(),
-		ContinueOnError:       plan.IngestionConfig.ContinueOnError.ValueBool(),
-		StreamIngestionConfig: &model.StreamIngestionConfig{
-			StreamConfigMaps: plan.IngestionConfig.StreamIngestionConfig.StreamConfigMaps,
-		},
+	upsertConfig := model.UpsertConfig{
+		Mode: plan.UpsertConfig.Mode.ValueString(),
+	}
+
+	if !plan.UpsertConfig.PartialUpsertStrategies.IsNull() {
+		partialUpsertStrategies := make(map[string]string)
+		plan.UpsertConfig.PartialUpsertStrategies.ElementsAs(ctx, &partialUpsertStrategies, false)
+		upsertConfig.PartialUpsertStrategies = partialUpsertStrategies
+	}
+
+	return &upsertConfig
+}
+
+func overrideIngestionConfig(ctx context.Context, plan *models.TableResourceModel) map[string]any {
+
+	ingestionConfig := map[string]any{}
+
+	setValue(ctx, ingestionConfig, "segmentTimeValueCheck", plan.IngestionConfig.SegmentTimeValueCheck)
+	setValue(ctx, ingestionConfig, "rowTimeValueCheck", plan.IngestionConfig.RowTimeValueCheck)
+	setValue(ctx, ingestionConfig, "continueOnError", plan.IngestionConfig.ContinueOnError)
+
+	if plan.IngestionConfig.StreamIngestionConfig != nil {
+		ingestionConfig["streamIngestionConfig"] = model.StreamIngestionConfig{
+			StreamConfigMaps: streamConfigMaps(ctx, plan.IngestionConfig.StreamIngestionConfig),
+		}
 	}
 
 	if plan.IngestionConfig.TransformConfigs != nil {
@@ -764,40 +2018,96 @@ func overrideIngestionConfig(plan *models.TableResourceModel) *model.TableIngest
 			})
 		}
 
-		ingestionConfig.TransformConfigs = transformConfigs
+		ingestionConfig["transformConfigs"] = transformConfigs
+	}
+
+	if plan.IngestionConfig.BatchIngestionConfig != nil {
+		batchIngestionConfig := map[string]any{}
+		setValue(ctx, batchIngestionConfig, "segmentIngestionType", plan.IngestionConfig.BatchIngestionConfig.SegmentIngestionType)
+		setValue(ctx, batchIngestionConfig, "segmentIngestionFrequency", plan.IngestionConfig.BatchIngestionConfig.SegmentIngestionFrequency)
+		setValue(ctx, batchIngestionConfig, "consistentDataPush", plan.IngestionConfig.BatchIngestionConfig.ConsistentDataPush)
+		if plan.IngestionConfig.BatchIngestionConfig.BatchConfigMaps != nil {
+			batchIngestionConfig["batchConfigMaps"] = plan.IngestionConfig.BatchIngestionConfig.BatchConfigMaps
+		}
+		ingestionConfig["batchIngestionConfig"] = batchIngestionConfig
+	}
+
+	if plan.IngestionConfig.FilterConfig != nil {
+		filterConfig := map[string]any{}
+		setValue(ctx, filterConfig, "filterFunction", plan.IngestionConfig.FilterConfig.FilterFunction)
+		ingestionConfig["filterConfig"] = filterConfig
+	}
+
+	if plan.IngestionConfig.ComplexTypeConfig != nil {
+		complexTypeConfig := map[string]any{}
+		setValue(ctx, complexTypeConfig, "fieldsToUnnest", plan.IngestionConfig.ComplexTypeConfig.FieldsToUnnest)
+		setValue(ctx, complexTypeConfig, "delimiter", plan.IngestionConfig.ComplexTypeConfig.Delimiter)
+		setValue(ctx, complexTypeConfig, "collectionNotUnnestedToJson", plan.IngestionConfig.ComplexTypeConfig.CollectionNotUnnestedToJSON)
+		setValue(ctx, complexTypeConfig, "prefixesToRename", plan.IngestionConfig.ComplexTypeConfig.PrefixesToRename)
+		ingestionConfig["complexTypeConfig"] = complexTypeConfig
+	}
+
+	if plan.IngestionConfig.AggregationConfigs != nil {
+
+		var aggregationConfigs []map[string]any
+
+		for _, aggregationConfig := range plan.IngestionConfig.AggregationConfigs {
+			aggregationConfigs = append(aggregationConfigs, map[string]any{
+				"columnName":          aggregationConfig.ColumnName.ValueString(),
+				"aggregationFunction": aggregationConfig.AggregationFunction.ValueString(),
+			})
+		}
+
+		ingestionConfig["aggregationConfigs"] = aggregationConfigs
 	}
 
-	return &ingestionConfig
+	return ingestionConfig
 }
 
-func overrideFieldConfigList(plan *models.TableResourceModel) []model.FieldConfig {
+// streamConfigMaps merges the kafka block into the first stream config map, keys set in the map take precedence.
+func streamConfigMaps(ctx context.Context, streamIngestionConfig *models.StreamIngestionConfig) []map[string]string {
 
-	if plan.FieldConfigList == nil {
-		return nil
+	if streamIngestionConfig.Kafka == nil {
+		return streamIngestionConfig.StreamConfigMaps
+	}
+
+	streamConfig := converter.KafkaStreamConfigMap(ctx, streamIngestionConfig.Kafka)
+
+	if len(streamIngestionConfig.StreamConfigMaps) == 0 {
+		return []map[string]string{streamConfig}
+	}
+
+	for key, value := range streamIngestionConfig.StreamConfigMaps[0] {
+		streamConfig[key] = value
 	}
 
-	var fieldConfigs []model.FieldConfig
+	return append([]map[string]string{streamConfig}, streamIngestionConfig.StreamConfigMaps[1:]...)
+}
+
+func overrideFieldConfigList(ctx context.Context, plan *models.TableResourceModel) []map[string]any {
+
+	var fieldConfigs []map[string]any
 	for _, fieldConfig := range plan.FieldConfigList {
 
-		fc := model.FieldConfig{
-			Name:         fieldConfig.Name.ValueString(),
-			EncodingType: fieldConfig.EncodingType.ValueString(),
-			IndexType:    fieldConfig.IndexType.ValueString(),
-			IndexTypes:   fieldConfig.IndexTypes,
+		fc := map[string]any{}
+		setValue(ctx, fc, "name", fieldConfig.Name)
+		setValue(ctx, fc, "encodingType", fieldConfig.EncodingType)
+		setValue(ctx, fc, "indexType", fieldConfig.IndexType)
+		setValue(ctx, fc, "compressionCodec", fieldConfig.CompressionCodec)
+		setValue(ctx, fc, "properties", fieldConfig.Properties)
+
+		if fieldConfig.IndexTypes != nil {
+			fc["indexTypes"] = fieldConfig.IndexTypes
 		}
 
 		if fieldConfig.TimestampConfig != nil {
-			fc.TimestampConfig = &model.TimestampConfig{
+			fc["timestampConfig"] = model.TimestampConfig{
 				Granularities: fieldConfig.TimestampConfig.Granularities,
 			}
 		}
 
 		if fieldConfig.Indexes != nil {
-			fc.Indexes = &model.FieldIndexes{
-				Inverted: &model.FiendIndexInverted{
-					Enabled: fieldConfig.Indexes.Inverted.Enabled.ValueString(),
-				},
-			}
+			fc["indexes"] = overrideFieldIndexes(ctx, fieldConfig.Indexes)
 		}
 
 		fieldConfigs = append(fieldConfigs, fc)
@@ -805,6 +2115,91 @@ func overrideFieldConfigList(plan *models.TableResourceModel) []model.FieldConfi
 	return fieldConfigs
 }
 
+func overrideFieldIndexes(ctx context.Context, fieldIndexes *models.FieldIndexes) map[string]any {
+
+	indexes := map[string]any{}
+
+	if fieldIndexes.Inverted != nil {
+		inverted := map[string]any{}
+		setValue(ctx, inverted, "enabled", fieldIndexes.Inverted.Enabled)
+		indexes["inverted"] = inverted
+	}
+
+	if fieldIndexes.JSON != nil {
+		jsonIndex := map[string]any{}
+		setValue(ctx, jsonIndex, "maxLevels", fieldIndexes.JSON.MaxLevels)
+		setValue(ctx, jsonIndex, "excludeArray", fieldIndexes.JSON.ExcludeArray)
+		setValue(ctx, jsonIndex, "disableCrossArrayUnnest", fieldIndexes.JSON.DisableCrossArrayUnnest)
+		setValue(ctx, jsonIndex, "includePaths", fieldIndexes.JSON.IncludePaths)
+		setValue(ctx, jsonIndex, "excludePaths", fieldIndexes.JSON.ExcludePaths)
+		setValue(ctx, jsonIndex, "excludeFields", fieldIndexes.JSON.ExcludeFields)
+		setValue(ctx, jsonIndex, "indexPaths", fieldIndexes.JSON.IndexPaths)
+		setValue(ctx, jsonIndex, "maxValueLength", fieldIndexes.JSON.MaxValueLength)
+		indexes["json"] = jsonIndex
+	}
+
+	if fieldIndexes.Text != nil {
+		text := map[string]any{}
+		setValue(ctx, text, "fst", fieldIndexes.Text.FSTType)
+		setValue(ctx, text, "rawValue", fieldIndexes.Text.RawValue)
+		setValue(ctx, text, "queryCache", fieldIndexes.Text.QueryCache)
+		setValue(ctx, text, "useANDForMultiTermQueries", fieldIndexes.Text.UseANDForMultiTermQueries)
+		setValue(ctx, text, "stopWordsInclude", fieldIndexes.Text.StopWordsInclude)
+		setValue(ctx, text, "stopWordsExclude", fieldIndexes.Text.StopWordsExclude)
+		setValue(ctx, text, "luceneUseCompoundFile", fieldIndexes.Text.LuceneUseCompoundFile)
+		setValue(ctx, text, "luceneMaxBufferSizeMB", fieldIndexes.Text.LuceneMaxBufferSizeMB)
+		setValue(ctx, text, "luceneAnalyzerClass", fieldIndexes.Text.LuceneAnalyzerClass)
+		indexes["text"] = text
+	}
+
+	if fieldIndexes.FST != nil {
+		fst := map[string]any{}
+		setValue(ctx, fst, "type", fieldIndexes.FST.Type)
+		indexes["fst"] = fst
+	}
+
+	if fieldIndexes.H3 != nil {
+		h3 := map[string]any{}
+		setValue(ctx, h3, "resolution", fieldIndexes.H3.Resolutions)
+		indexes["h3"] = h3
+	}
+
+	if fieldIndexes.Range != nil {
+		rangeIndex := map[string]any{}
+		setValue(ctx, rangeIndex, "version", fieldIndexes.Range.Version)
+		indexes["range"] = rangeIndex
+	}
+
+	if fieldIndexes.Bloom != nil {
+		bloom := map[string]any{}
+		setValue(ctx, bloom, "fpp", fieldIndexes.Bloom.FPP)
+		setValue(ctx, bloom, "maxSizeInBytes", fieldIndexes.Bloom.MaxSizeInBytes)
+		setValue(ctx, bloom, "loadOnHeap", fieldIndexes.Bloom.LoadOnHeap)
+		indexes["bloom"] = bloom
+	}
+
+	if fieldIndexes.Forward != nil {
+		forward := map[string]any{}
+		setValue(ctx, forward, "disabled", fieldIndexes.Forward.Disabled)
+		setValue(ctx, forward, "compressionCodec", fieldIndexes.Forward.CompressionCodec)
+		setValue(ctx, forward, "deriveNumDocsPerChunk", fieldIndexes.Forward.DeriveNumDocsPerChunk)
+		setValue(ctx, forward, "rawIndexWriterVersion", fieldIndexes.Forward.RawIndexWriterVersion)
+		indexes["forward"] = forward
+	}
+
+	if fieldIndexes.Vector != nil {
+		vector := map[string]any{}
+		setValue(ctx, vector, "vectorIndexType", fieldIndexes.Vector.VectorIndexType)
+		setValue(ctx, vector, "vectorDimension", fieldIndexes.Vector.VectorDimension)
+		setValue(ctx, vector, "vectorDistanceFunction", fieldIndexes.Vector.VectorDistanceFunction)
+		setValue(ctx, vector, "version", fieldIndexes.Vector.Version)
+		setValue(ctx, vector, "properties", fieldIndexes.Vector.Properties)
+		indexes["vector"] = vector
+	}
+
+	return indexes
+}
+
 func toStringList(ctx context.Context, listValue types.List) []string {
 	var values []string
 	listValue.ElementsAs(ctx, &values, true)
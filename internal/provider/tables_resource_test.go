@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pinot_testContainer "github.com/azaurus1/pinot-testContainer"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccTableResource(t *testing.T) {
+
+	context, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	pinot, err := pinot_testContainer.RunPinotContainer(context)
+	if err != nil {
+		t.Fatalf("Failed to run Pinot container: %v", err)
+	}
+
+	providerConfig := fmt.Sprintf(`
+provider "pinot" {
+	controller_url = "http://%s"
+	auth_token = "YWRtaW46dmVyeXNlY3JldA"
+}
+
+resource "pinot_schema" "events" {
+	schema_name = "events"
+	dimension_field_specs = [{
+		name      = "id"
+		data_type = "STRING"
+	}]
+	date_time_field_specs = [{
+		name        = "ts"
+		data_type   = "LONG"
+		format      = "1:MILLISECONDS:EPOCH"
+		granularity = "1:MILLISECONDS"
+	}]
+}
+`, pinot.URI)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Every default the controller fills in has to be ignored, otherwise the refreshed table shows a diff
+			{
+				Config: providerConfig + `
+resource "pinot_table" "events" {
+	table_name = "events"
+	table_type = "OFFLINE"
+	table      = jsonencode({
+		tableName      = "events"
+		tableType      = "OFFLINE"
+		segmentsConfig = { replication = "1", timeColumnName = "ts", timeType = "MILLISECONDS" }
+		tenants        = {}
+		tableIndexConfig = { invertedIndexColumns = ["id"] }
+		fieldConfigList  = [{ name = "id", indexTypes = ["INVERTED"] }]
+		metadata         = {}
+	})
+
+	depends_on = [pinot_schema.events]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pinot_table.events", "table_name", "events"),
+					resource.TestCheckResourceAttrSet("pinot_table.events", "effective_config_json"),
+				),
+			},
+			// Reformatting and reordering the table definition does not plan an update
+			{
+				Config: providerConfig + `
+resource "pinot_table" "events" {
+	table_name = "events"
+	table_type = "OFFLINE"
+	table      = <<-EOT
+		{
+			"tableType": "OFFLINE",
+			"tableName": "events",
+			"metadata": {},
+			"fieldConfigList": [{"indexTypes": ["INVERTED"], "name": "id"}],
+			"tableIndexConfig": {"invertedIndexColumns": ["id"]},
+			"segmentsConfig": {"timeType": "MILLISECONDS", "timeColumnName": "ts", "replication": "1"},
+			"tenants": {}
+		}
+	EOT
+
+	depends_on = [pinot_schema.events]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Adding a block that repeats the time column of the existing table updates it in place
+			{
+				Config: providerConfig + `
+resource "pinot_table" "events" {
+	table_name = "events"
+	table_type = "OFFLINE"
+	table      = jsonencode({
+		tableName      = "events"
+		tableType      = "OFFLINE"
+		segmentsConfig = { replication = "1", timeColumnName = "ts", timeType = "MILLISECONDS" }
+		tenants        = {}
+		tableIndexConfig = { invertedIndexColumns = ["id"] }
+		fieldConfigList  = [{ name = "id", indexTypes = ["INVERTED"] }]
+		metadata         = {}
+	})
+
+	segments_config = {
+		replication      = "1"
+		time_type        = "MILLISECONDS"
+		time_column_name = "ts"
+	}
+
+	depends_on = [pinot_schema.events]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pinot_table.events", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestTableSchemaName(t *testing.T) {
+
+	testCases := map[string]struct {
+		tableConfig map[string]any
+		schemaName  string
+		ok          bool
+	}{
+		"table name": {
+			tableConfig: map[string]any{"segmentsConfig": map[string]any{"replication": "1"}},
+			schemaName:  "events",
+			ok:          true,
+		},
+		"schema name": {
+			tableConfig: map[string]any{"segmentsConfig": map[string]any{"schemaName": "shared_events"}},
+			schemaName:  "shared_events",
+			ok:          true,
+		},
+		"empty schema name": {
+			tableConfig: map[string]any{"segmentsConfig": map[string]any{"schemaName": ""}},
+			ok:          false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			schemaName, ok := tableSchemaName("events", testCase.tableConfig)
+			if schemaName != testCase.schemaName || ok != testCase.ok {
+				t.Errorf("expected %q %t, got %q %t", testCase.schemaName, testCase.ok, schemaName, ok)
+			}
+		})
+	}
+}
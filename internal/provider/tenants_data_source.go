@@ -3,10 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
+	"github.com/azaurus1/go-pinot-api/model"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,14 +30,29 @@ type tenantsDataSource struct {
 }
 
 type tenantsDataSourceModel struct {
-	ServerTenants []tenantsModel `tfsdk:"server_tenants"`
-	BrokerTenants []tenantsModel `tfsdk:"broker_tenants"`
+	TenantName    types.String        `tfsdk:"tenant_name"`
+	ServerTenants []tenantsModel      `tfsdk:"server_tenants"`
+	BrokerTenants []tenantsModel      `tfsdk:"broker_tenants"`
+	Tenants       []tenantDetailModel `tfsdk:"tenants"`
 }
 
 type tenantsModel struct {
 	TenantName string `tfsdk:"tenant_name"`
 }
 
+type tenantDetailModel struct {
+	TenantName                 string   `tfsdk:"tenant_name"`
+	OfflineServerInstances     []string `tfsdk:"offline_server_instances"`
+	RealtimeServerInstances    []string `tfsdk:"realtime_server_instances"`
+	BrokerInstances            []string `tfsdk:"broker_instances"`
+	Tables                     []string `tfsdk:"tables"`
+	NumServerInstances         int64    `tfsdk:"num_server_instances"`
+	NumOfflineServerInstances  int64    `tfsdk:"num_offline_server_instances"`
+	NumRealtimeServerInstances int64    `tfsdk:"num_realtime_server_instances"`
+	NumBrokerInstances         int64    `tfsdk:"num_broker_instances"`
+	NumTables                  int64    `tfsdk:"num_tables"`
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *tenantsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -62,6 +81,10 @@ func (d *tenantsDataSource) Metadata(_ context.Context, req datasource.MetadataR
 func (d *tenantsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"tenant_name": schema.StringAttribute{
+				Description: "Only return the tenant with this name.",
+				Optional:    true,
+			},
 			"server_tenants": schema.ListNestedAttribute{
 				Description: "Server tenants",
 				Computed:    true,
@@ -86,6 +109,58 @@ func (d *tenantsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 					},
 				},
 			},
+			"tenants": schema.ListNestedAttribute{
+				Description: "The server and broker tenants with their instances and tables, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tenant_name": schema.StringAttribute{
+							Description: "The name of the tenant.",
+							Computed:    true,
+						},
+						"offline_server_instances": schema.ListAttribute{
+							Description: "The servers of the tenant that host offline segments.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"realtime_server_instances": schema.ListAttribute{
+							Description: "The servers of the tenant that host realtime segments.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"broker_instances": schema.ListAttribute{
+							Description: "The brokers of the tenant.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"tables": schema.ListAttribute{
+							Description: "The tables that use the tenant as their server or broker tenant.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"num_server_instances": schema.Int64Attribute{
+							Description: "The number of servers of the tenant.",
+							Computed:    true,
+						},
+						"num_offline_server_instances": schema.Int64Attribute{
+							Description: "The number of offline servers of the tenant.",
+							Computed:    true,
+						},
+						"num_realtime_server_instances": schema.Int64Attribute{
+							Description: "The number of realtime servers of the tenant.",
+							Computed:    true,
+						},
+						"num_broker_instances": schema.Int64Attribute{
+							Description: "The number of brokers of the tenant.",
+							Computed:    true,
+						},
+						"num_tables": schema.Int64Attribute{
+							Description: "The number of tables of the tenant.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -110,19 +185,115 @@ func (d *tenantsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	if !state.TenantName.IsNull() && !contains(tenants.ServerTenants, state.TenantName.ValueString()) &&
+		!contains(tenants.BrokerTenants, state.TenantName.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tenant_name"),
+			"Tenant Not Found",
+			fmt.Sprintf("There is no server or broker tenant %s.", state.TenantName.ValueString()),
+		)
+		return
+	}
+
+	// a tenant name can be both a server and a broker tenant, the details are returned once per name
+	var tenantNames []string
+
 	for _, brokerTenant := range tenants.BrokerTenants {
+		if !state.TenantName.IsNull() && brokerTenant != state.TenantName.ValueString() {
+			continue
+		}
 		state.BrokerTenants = append(state.BrokerTenants, tenantsModel{
 			TenantName: brokerTenant,
 		})
+		tenantNames = append(tenantNames, brokerTenant)
 	}
 
 	for _, serverTenant := range tenants.ServerTenants {
+		if !state.TenantName.IsNull() && serverTenant != state.TenantName.ValueString() {
+			continue
+		}
 		state.ServerTenants = append(state.ServerTenants, tenantsModel{
 			TenantName: serverTenant,
 		})
+		if !contains(tenantNames, serverTenant) {
+			tenantNames = append(tenantNames, serverTenant)
+		}
+	}
+
+	sort.Strings(tenantNames)
+
+	state.Tenants = make([]tenantDetailModel, len(tenantNames))
+	err = forEachConcurrently(len(tenantNames), func(i int) error {
+		tenantDetail, err := getTenantDetail(d.client, tenantNames[i])
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", tenantNames[i], err)
+		}
+		state.Tenants[i] = *tenantDetail
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to get tenant",
+			fmt.Sprintf("Failed to get tenant: %s", err),
+		)
+
+		return
 	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 
 }
+
+// getTenantDetail fetches the instances of a tenant and the tables that use it as their server or broker tenant.
+func getTenantDetail(client *goPinotAPI.PinotAPIClient, tenantName string) (*tenantDetailModel, error) {
+
+	metadata, err := client.GetTenantMetadata(tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverTables, err := client.GetTenantTables(tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetTenantTables only returns the tables of server tenants
+	var brokerTables model.GetTablesResponse
+	err = client.FetchData(fmt.Sprintf("/tenants/%s/tables?type=broker", tenantName), &brokerTables)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTenantDetail(tenantName, metadata, append(serverTables.Tables, brokerTables.Tables...)), nil
+}
+
+func newTenantDetail(tenantName string, metadata *model.GetTenantMetadataResponse, tables []string) *tenantDetailModel {
+
+	tenantTables := []string{}
+	for _, table := range tables {
+		if !contains(tenantTables, table) {
+			tenantTables = append(tenantTables, table)
+		}
+	}
+	sort.Strings(tenantTables)
+
+	return &tenantDetailModel{
+		TenantName:                 tenantName,
+		OfflineServerInstances:     sortedInstances(metadata.OfflineServerInstances),
+		RealtimeServerInstances:    sortedInstances(metadata.RealtimeServerInstances),
+		BrokerInstances:            sortedInstances(metadata.BrokerInstances),
+		Tables:                     tenantTables,
+		NumServerInstances:         int64(len(metadata.ServerInstances)),
+		NumOfflineServerInstances:  int64(len(metadata.OfflineServerInstances)),
+		NumRealtimeServerInstances: int64(len(metadata.RealtimeServerInstances)),
+		NumBrokerInstances:         int64(len(metadata.BrokerInstances)),
+		NumTables:                  int64(len(tenantTables)),
+	}
+}
+
+func sortedInstances(instances []string) []string {
+	sorted := append([]string{}, instances...)
+	sort.Strings(sorted)
+	return sorted
+}
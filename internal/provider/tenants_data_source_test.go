@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/azaurus1/go-pinot-api/model"
+)
+
+func TestNewTenantDetail(t *testing.T) {
+
+	metadata := &model.GetTenantMetadataResponse{
+		ServerInstances:         []string{"Server_pinot-server-1_8098", "Server_pinot-server-0_8098"},
+		OfflineServerInstances:  []string{"Server_pinot-server-1_8098", "Server_pinot-server-0_8098"},
+		RealtimeServerInstances: []string{"Server_pinot-server-0_8098"},
+		BrokerInstances:         []string{"Broker_pinot-broker-0_8099"},
+		TenantName:              "DefaultTenant",
+	}
+
+	tenant := newTenantDetail("DefaultTenant", metadata, []string{"events_REALTIME", "airlines_OFFLINE", "events_REALTIME"})
+
+	if !reflect.DeepEqual(tenant.OfflineServerInstances, []string{"Server_pinot-server-0_8098", "Server_pinot-server-1_8098"}) {
+		t.Errorf("expected the sorted offline servers, got %v", tenant.OfflineServerInstances)
+	}
+
+	if !reflect.DeepEqual(tenant.Tables, []string{"airlines_OFFLINE", "events_REALTIME"}) {
+		t.Errorf("expected the tables once each, got %v", tenant.Tables)
+	}
+
+	if tenant.NumServerInstances != 2 || tenant.NumRealtimeServerInstances != 1 || tenant.NumBrokerInstances != 1 || tenant.NumTables != 2 {
+		t.Errorf("unexpected counts %+v", tenant)
+	}
+}
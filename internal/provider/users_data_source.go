@@ -2,11 +2,17 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -27,15 +33,20 @@ type usersDataSource struct {
 }
 
 type usersDataSourceModel struct {
-	Users []usersModel `tfsdk:"users"`
-	ID    types.String `tfsdk:"id"`
+	Component       types.String `tfsdk:"component"`
+	Role            types.String `tfsdk:"role"`
+	UsernamePattern types.String `tfsdk:"username_pattern"`
+	Users           []usersModel `tfsdk:"users"`
+	ID              types.String `tfsdk:"id"`
 }
 
 type usersModel struct {
-	Username  string `tfsdk:"username"`
-	Password  string `tfsdk:"password"`
-	Component string `tfsdk:"component"`
-	Role      string `tfsdk:"role"`
+	Username      string   `tfsdk:"username"`
+	Component     string   `tfsdk:"component"`
+	Role          string   `tfsdk:"role"`
+	Tables        []string `tfsdk:"tables"`
+	ExcludeTables []string `tfsdk:"exclude_tables"`
+	Permissions   []string `tfsdk:"permissions"`
 }
 
 // Configure adds the provider configured client to the data source.
@@ -67,9 +78,21 @@ func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "The ID of the user.",
+				Description: "A hash of the users found, it only changes when the set of users does.",
 				Computed:    true,
 			},
+			"component": schema.StringAttribute{
+				Description: "Only return the users of this component, e.g. BROKER.",
+				Optional:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "Only return the users with this role, e.g. ADMIN.",
+				Optional:    true,
+			},
+			"username_pattern": schema.StringAttribute{
+				Description: "Only return the users whose username matches this regular expression.",
+				Optional:    true,
+			},
 			"users": schema.ListNestedAttribute{
 				Description: "The list of users.",
 				Computed:    true,
@@ -79,11 +102,6 @@ func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Description: "The username of the user.",
 							Computed:    true,
 						},
-						"password": schema.StringAttribute{
-							Description: "The password of the user.",
-							Computed:    true,
-							// Sensitive:   true,
-						},
 						"component": schema.StringAttribute{
 							Description: "The component of the user.",
 							Computed:    true,
@@ -92,6 +110,21 @@ func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							Description: "The role of the user.",
 							Computed:    true,
 						},
+						"tables": schema.ListAttribute{
+							Description: "The tables the user can access, empty when the user can access all tables.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"exclude_tables": schema.ListAttribute{
+							Description: "The tables the user cannot access.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"permissions": schema.ListAttribute{
+							Description: "The access types the user has on its tables.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
 					},
 				},
 			},
@@ -102,25 +135,68 @@ func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 // Read refreshes the Terraform state with the latest data.
 func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state usersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	usersResp, err := d.client.GetUsers()
+	var usernamePattern *regexp.Regexp
+	if !state.UsernamePattern.IsNull() {
+		var err error
+		usernamePattern, err = regexp.Compile(state.UsernamePattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("username_pattern"), "Invalid Username Pattern", err.Error())
+			return
+		}
+	}
+
+	// the pinot model leaves out the table access control fields
+	var usersResp struct {
+		Users map[string]pinotUser `json:"users"`
+	}
+	err := d.client.FetchData("/users", &usersResp)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get users", fmt.Sprintf("Failed to get users: %s", err))
 		return
 	}
 
-	for _, user := range usersResp.Users {
+	// users come keyed by username and component, sort them so the list and the ID do not change between reads
+	keys := make([]string, 0, len(usersResp.Users))
+	for key := range usersResp.Users {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found []string
+	for _, key := range keys {
+		user := usersResp.Users[key]
+
+		if !state.Component.IsNull() && !strings.EqualFold(user.Component, state.Component.ValueString()) {
+			continue
+		}
+		if !state.Role.IsNull() && !strings.EqualFold(user.Role, state.Role.ValueString()) {
+			continue
+		}
+		if usernamePattern != nil && !usernamePattern.MatchString(user.Username) {
+			continue
+		}
+
 		state.Users = append(state.Users, usersModel{
-			Username:  user.Username,
-			Password:  user.Password,
-			Component: user.Component,
-			Role:      user.Role,
+			Username:      user.Username,
+			Component:     user.Component,
+			Role:          user.Role,
+			Tables:        user.Tables,
+			ExcludeTables: user.ExcludeTables,
+			Permissions:   user.Permissions,
 		})
+		found = append(found, key)
 	}
 
-	state.ID = types.StringValue("placeholder")
+	sum := sha256.Sum256([]byte(strings.Join(found, ",")))
+	state.ID = types.StringValue(hex.EncodeToString(sum[:]))
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
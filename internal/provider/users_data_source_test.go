@@ -34,8 +34,19 @@ provider "pinot" {
 			{
 				Config: providerConfig + `data "pinot_users" "test" {}`,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr("data.pinot_users.test", "id", "placeholder"), // Test placeholder ID is created
-					resource.TestCheckResourceAttr("data.pinot_users.test", "users.#", "0"),      // No users when running the docker compose
+					resource.TestCheckResourceAttrSet("data.pinot_users.test", "id"),
+					resource.TestCheckResourceAttr("data.pinot_users.test", "users.#", "0"), // No users when running the docker compose
+				),
+			},
+			{
+				Config: providerConfig + `data "pinot_users" "test" {
+	component        = "BROKER"
+	role             = "ADMIN"
+	username_pattern = "^analyst_"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pinot_users.test", "id"),
+					resource.TestCheckResourceAttr("data.pinot_users.test", "users.#", "0"),
 				),
 			},
 		},
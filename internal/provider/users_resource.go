@@ -3,19 +3,29 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
+	"strings"
 
 	goPinotAPI "github.com/azaurus1/go-pinot-api"
-	goPinotModel "github.com/azaurus1/go-pinot-api/model"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	_ resource.Resource              = &userResource{}
-	_ resource.ResourceWithConfigure = &userResource{}
+	_ resource.Resource                   = &userResource{}
+	_ resource.ResourceWithConfigure      = &userResource{}
+	_ resource.ResourceWithValidateConfig = &userResource{}
 )
 
+// userPermissions are the access types a user can be granted on its tables.
+var userPermissions = []string{"READ", "CREATE", "UPDATE", "DELETE"}
+
 func NewUserResource() resource.Resource {
 	return &userResource{}
 }
@@ -25,10 +35,95 @@ type userResource struct {
 }
 
 type userResourceModel struct {
-	Username  string `tfsdk:"username"`
-	Password  string `tfsdk:"password"`
-	Component string `tfsdk:"component"`
-	Role      string `tfsdk:"role"`
+	Username        string       `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	PasswordWO      types.String `tfsdk:"password_wo"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+	Component       types.String `tfsdk:"component"`
+	Components      []string     `tfsdk:"components"`
+	Role            string       `tfsdk:"role"`
+	Tables          []string     `tfsdk:"tables"`
+	ExcludeTables   []string     `tfsdk:"exclude_tables"`
+	Permissions     []string     `tfsdk:"permissions"`
+}
+
+// pinotUser is the user config of the controller. The pinot model leaves out the table access control fields.
+type pinotUser struct {
+	Username      string   `json:"username"`
+	Password      string   `json:"password"`
+	Component     string   `json:"component"`
+	Role          string   `json:"role"`
+	Tables        []string `json:"tables,omitempty"`
+	ExcludeTables []string `json:"excludeTables,omitempty"`
+	Permissions   []string `json:"permissions,omitempty"`
+}
+
+// password returns the password to send to the controller, from whichever of password and password_wo is set.
+func (m *userResourceModel) password() string {
+	if !m.PasswordWO.IsNull() {
+		return m.PasswordWO.ValueString()
+	}
+	return m.Password.ValueString()
+}
+
+// components returns the components the user is in, from whichever of component and components is set.
+func (m *userResourceModel) components() []string {
+	if m.Components != nil {
+		return m.Components
+	}
+	return []string{m.Component.ValueString()}
+}
+
+// user returns the user config to send to the controller, without a component.
+func (m *userResourceModel) user(password string) pinotUser {
+	return pinotUser{
+		Username:      m.Username,
+		Password:      password,
+		Role:          m.Role,
+		Tables:        m.Tables,
+		ExcludeTables: m.ExcludeTables,
+		Permissions:   m.Permissions,
+	}
+}
+
+// sameElements reports whether a and b hold the same strings, in any order.
+func sameElements(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, value := range a {
+		counts[value]++
+	}
+	for _, value := range b {
+		if counts[value] == 0 {
+			return false
+		}
+		counts[value]--
+	}
+	return true
+}
+
+// passwordMatches reports whether password is the one the controller stored as hash.
+// Null passwords are not checked, there is nothing to compare them to.
+func passwordMatches(hash string, password string) bool {
+	if password == "" || hash == password {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// getUser fetches the user config of username for the given component.
+func getUser(client *goPinotAPI.PinotAPIClient, username string, component string) (*pinotUser, error) {
+
+	var users map[string]pinotUser
+	err := client.FetchData(fmt.Sprintf("/users/%s?component=%s", username, component), &users)
+	if err != nil {
+		return nil, err
+	}
+
+	user := users[fmt.Sprintf("%s_%s", username, component)]
+	return &user, nil
 }
 
 func (r *userResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -57,26 +152,144 @@ func (r *userResource) Schema(_ context.Context, req resource.SchemaRequest, res
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				Description: "The username of the user.",
+				Description: "The username of the user. Changing this forces a new user to be created.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"password": schema.StringAttribute{
-				Description: "The password of the user.",
-				Required:    true,
+				Description: "The password of the user. The controller stores a bcrypt hash of it, which is checked against the password on refresh to detect a rotation outside of Terraform. Conflicts with password_wo.",
+				Optional:    true,
 				Sensitive:   true,
 			},
+			"password_wo": schema.StringAttribute{
+				Description: "The password of the user as a write-only attribute, it is never stored in the plan or state. Requires Terraform 1.11 or later. " +
+					"It is sent to the controller on create and whenever password_version changes, a rotation outside of Terraform is not detected. Conflicts with password.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_version": schema.Int64Attribute{
+				Description: "Changing this sends password_wo to the controller again, for example to rotate a password read from a secret store under the same name. Requires password_wo.",
+				Optional:    true,
+			},
 			"component": schema.StringAttribute{
-				Description: "The component of the user.",
-				Required:    true,
+				Description: "The component of the user. Conflicts with components.",
+				Optional:    true,
+			},
+			"components": schema.SetAttribute{
+				Description: "The components of the user, e.g. CONTROLLER and BROKER. The user is created, updated and deleted in all of them, " +
+					"a create that fails for one component is rolled back in the others. Conflicts with component.",
+				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"role": schema.StringAttribute{
 				Description: "The role of the user.",
 				Required:    true,
 			},
+			"tables": schema.SetAttribute{
+				Description: "The tables the user can access. The user can access all tables when unset.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"exclude_tables": schema.SetAttribute{
+				Description: "The tables the user cannot access, for a user that can access all tables but a few.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"permissions": schema.SetAttribute{
+				Description: "The access types the user has on its tables, any of READ, CREATE, UPDATE and DELETE.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
 
+func (r *userResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+
+	var password, passwordWO types.String
+	var passwordVersion types.Int64
+	var permissions types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_version"), &passwordVersion)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("permissions"), &permissions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !password.IsNull() && !passwordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting Password Attributes",
+			"Only one of password and password_wo can be set.",
+		)
+	}
+
+	if password.IsNull() && passwordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Password",
+			"One of password and password_wo must be set.",
+		)
+	}
+
+	var component types.String
+	var components types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("component"), &component)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("components"), &components)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !component.IsNull() && !components.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("components"),
+			"Conflicting Component Attributes",
+			"Only one of component and components can be set.",
+		)
+	}
+
+	if component.IsNull() && components.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("component"),
+			"Missing Component",
+			"One of component and components must be set.",
+		)
+	}
+
+	if !components.IsNull() && !components.IsUnknown() && len(components.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("components"),
+			"Missing Component",
+			"components must hold at least one component.",
+		)
+	}
+
+	if !passwordVersion.IsNull() && passwordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_version"),
+			"Password Version Without password_wo",
+			"password_version only triggers sending password_wo, set password_wo or remove password_version.",
+		)
+	}
+
+	// the controller reads back the upper case name, so a lower case permission would always show a diff
+	for _, element := range permissions.Elements() {
+		permission, ok := element.(types.String)
+		if !ok || permission.IsUnknown() || permission.IsNull() || contains(userPermissions, permission.ValueString()) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("permissions"),
+			"Invalid Permission",
+			fmt.Sprintf("%q is not a permission, expected one of %s.", permission.ValueString(), strings.Join(userPermissions, ", ")),
+		)
+	}
+}
+
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan userResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -85,27 +298,20 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	// Generate API request from plan
-	// Convert plan into []byte
-
-	user := goPinotModel.User{
-		Username:  plan.Username,
-		Password:  plan.Password,
-		Component: plan.Component,
-		Role:      plan.Role,
-	}
-
-	userBytes, err := json.Marshal(user)
-	if err != nil {
-		log.Panic(err)
+	// write-only values are only in config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &plan.PasswordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	_, err = r.client.CreateUser(userBytes)
+	err := r.createUsers(plan.components(), plan.user(plan.password()))
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create user", err.Error())
 		return
 	}
 
+	plan.PasswordWO = types.StringNull()
+
 	// set state to populated data
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -123,16 +329,53 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	user, err := r.client.GetUser(state.Username, state.Component)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to get user", err.Error())
+	var users []*pinotUser
+	var components []string
+	for _, component := range state.components() {
+		user, err := getUser(r.client, state.Username, component)
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Failed to get user", err.Error())
+			return
+		}
+		// a component the user was removed from is dropped, so the next apply creates it again
+		if err != nil || user.Username == "" {
+			continue
+		}
+		users = append(users, user)
+		components = append(components, component)
+	}
+
+	if len(users) == 0 {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	state.Username = user.Username
-	state.Password = user.Password
-	state.Component = user.Component
-	state.Role = user.Role
+	if state.Components != nil {
+		state.Components = components
+	}
+
+	// every component holds its own copy of the user, the first one that differs from state shows the drift
+	live := *users[0]
+	for _, user := range users {
+		if user.Role != state.Role || !sameElements(user.Tables, state.Tables) ||
+			!sameElements(user.ExcludeTables, state.ExcludeTables) || !sameElements(user.Permissions, state.Permissions) {
+			live = *user
+			break
+		}
+	}
+
+	// the controller returns the bcrypt hash of the password, keep the configured password unless it no longer matches
+	for _, user := range users {
+		if !passwordMatches(user.Password, state.Password.ValueString()) {
+			state.Password = types.StringValue(user.Password)
+		}
+	}
+
+	state.Username = live.Username
+	state.Role = live.Role
+	state.Tables = live.Tables
+	state.ExcludeTables = live.ExcludeTables
+	state.Permissions = live.Permissions
 
 	// set state to populated data
 	diags = resp.State.Set(ctx, &state)
@@ -160,41 +403,96 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	var passwordChanged = false
-	var user goPinotModel.User
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_wo"), &plan.PasswordWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if state.Password != plan.Password {
-		passwordChanged = true
-		user = goPinotModel.User{
-			Username:  plan.Username,
-			Password:  plan.Password,
-			Component: plan.Component,
-			Role:      plan.Role,
+	// password_wo is never in state, so it is only sent again when password_version changes or when it
+	// replaces password
+	passwordChanged := !plan.Password.Equal(state.Password) || !plan.PasswordVersion.Equal(state.PasswordVersion)
+
+	var added, kept, removed []string
+	for _, component := range plan.components() {
+		if containsFold(state.components(), component) {
+			kept = append(kept, component)
+		} else {
+			added = append(added, component)
 		}
-	} else {
-		user = goPinotModel.User{
-			Username:  plan.Username,
-			Password:  state.Password,
-			Component: plan.Component,
-			Role:      plan.Role,
+	}
+	for _, component := range state.components() {
+		if !containsFold(plan.components(), component) {
+			removed = append(removed, component)
 		}
-
 	}
 
-	// Generate API request from plan
-	// Convert plan into []byte
+	// the live configs are the rollback point, and with passwordChanged unset the controller stores the
+	// password as given, so they also carry the hash to send
+	liveUsers := make([]*pinotUser, 0, len(kept))
+	for _, component := range kept {
+		liveUser, err := getUser(r.client, state.Username, component)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get user", err.Error())
+			return
+		}
+		liveUsers = append(liveUsers, liveUser)
+	}
 
-	userBytes, err := json.Marshal(user)
+	err := r.createUsers(added, plan.user(plan.password()))
 	if err != nil {
-		log.Panic(err)
+		resp.Diagnostics.AddError("Failed to update user", err.Error())
+		return
 	}
 
-	_, err = r.client.UpdateUser(plan.Username, plan.Component, passwordChanged, userBytes)
-	if err != nil {
+	for i, component := range kept {
+
+		password := plan.password()
+		if !passwordChanged {
+			password = liveUsers[i].Password
+		}
+
+		user := plan.user(password)
+		user.Component = component
+
+		err = r.updateUser(user, passwordChanged)
+		if err == nil {
+			continue
+		}
+
+		// restore the components updated so far and remove the ones created above
+		for _, liveUser := range liveUsers[:i] {
+			if rollbackErr := r.updateUser(*liveUser, false); rollbackErr != nil {
+				err = fmt.Errorf("%w; rolling back component %s: %s", err, liveUser.Component, rollbackErr)
+			}
+		}
+		err = r.deleteUsers(plan.Username, added, err)
+
 		resp.Diagnostics.AddError("Failed to update user", err.Error())
 		return
 	}
 
+	// removals come last, a deleted user cannot be restored without its password
+	var deleteErr error
+	remaining := append([]string{}, plan.components()...)
+	for _, component := range removed {
+		_, err := r.client.DeleteUser(state.Username, component)
+		if err != nil && !isNotFound(err) {
+			deleteErr = errors.Join(deleteErr, fmt.Errorf("component %s: %w", component, err))
+			remaining = append(remaining, component)
+		}
+	}
+
+	if deleteErr != nil {
+		resp.Diagnostics.AddError("Failed to update user", fmt.Sprintf("Failed to remove the user from components: %s", deleteErr))
+		// keep the components the user is still in, so the next apply removes them again
+		if plan.Components == nil {
+			return
+		}
+		plan.Components = remaining
+	}
+
+	plan.PasswordWO = types.StringNull()
+
 	// set state to populated data
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -212,16 +510,80 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	_, err := r.client.DeleteUser(state.Username, state.Component)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete user", err.Error())
-		return
+	var remaining []string
+	var deleteErr error
+	for _, component := range state.components() {
+		_, err := r.client.DeleteUser(state.Username, component)
+		if err != nil && !isNotFound(err) {
+			deleteErr = errors.Join(deleteErr, fmt.Errorf("component %s: %w", component, err))
+			remaining = append(remaining, component)
+		}
 	}
 
-	// set state to populated data
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	if deleteErr == nil {
 		return
 	}
+
+	resp.Diagnostics.AddError("Failed to delete user", deleteErr.Error())
+
+	// keep the components the user is still in, so deleting again does not touch the others
+	if state.Components != nil {
+		state.Components = remaining
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+// createUsers creates user in each of components. When one fails, the ones created before it are deleted again,
+// so the user either ends up in all components or in none.
+func (r *userResource) createUsers(components []string, user pinotUser) error {
+
+	var created []string
+	for _, component := range components {
+
+		user.Component = component
+
+		userBytes, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.client.CreateUser(userBytes)
+		if err != nil {
+			return r.deleteUsers(user.Username, created, fmt.Errorf("component %s: %w", component, err))
+		}
+
+		created = append(created, component)
+	}
+
+	return nil
+}
+
+// updateUser sends user to the controller, passwordChanged tells it whether the password still has to be hashed.
+func (r *userResource) updateUser(user pinotUser, passwordChanged bool) error {
+
+	userBytes, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.UpdateUser(user.Username, user.Component, passwordChanged, userBytes)
+	if err != nil {
+		return fmt.Errorf("component %s: %w", user.Component, err)
+	}
+
+	return nil
+}
+
+// deleteUsers rolls back the creation of username in components after err, adding any failure to roll back to it.
+func (r *userResource) deleteUsers(username string, components []string, err error) error {
+
+	for _, component := range components {
+		_, deleteErr := r.client.DeleteUser(username, component)
+		if deleteErr != nil {
+			err = fmt.Errorf("%w; rolling back component %s: %s", err, component, deleteErr)
+		}
+	}
+
+	return err
 }
@@ -8,6 +8,8 @@ import (
 
 	pinot_testContainer "github.com/azaurus1/pinot-testContainer"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestAccUsersResource(t *testing.T) {
@@ -39,10 +41,6 @@ resource "pinot_user" "test" {
 	password  = "password"
 	component = "BROKER"
 	role      = "USER"
-				  
-	lifecycle {
-		ignore_changes = [password]
-		}
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -66,20 +64,76 @@ resource "pinot_user" "test" {
 	password  = "password"
 	component = "BROKER"
 	role      = "ADMIN"
-
-	lifecycle {
-		ignore_changes = [password]
-		}
+	tables      = ["analytics_events"]
+	permissions = ["READ"]
 }
 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("pinot_user.test", "username", "user"),
-					// resource.TestCheckResourceAttr("pinot_user.test", "password", "password"), // This is ignored because it returns the salted and hashed password AGAIN
+					resource.TestCheckResourceAttr("pinot_user.test", "password", "password"),
 					resource.TestCheckResourceAttr("pinot_user.test", "component", "BROKER"),
 					resource.TestCheckResourceAttr("pinot_user.test", "role", "ADMIN"),
+					resource.TestCheckResourceAttr("pinot_user.test", "tables.#", "1"),
+					resource.TestCheckTypeSetElemAttr("pinot_user.test", "tables.*", "analytics_events"),
+					resource.TestCheckTypeSetElemAttr("pinot_user.test", "permissions.*", "READ"),
+				),
+			},
+			// Moving the user to several components
+			{
+				Config: providerConfig + `
+resource "pinot_user" "test" {
+	username   = "user"
+	password   = "password"
+	components = ["BROKER", "CONTROLLER"]
+	role       = "ADMIN"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pinot_user.test", "components.#", "2"),
+					resource.TestCheckTypeSetElemAttr("pinot_user.test", "components.*", "BROKER"),
+					resource.TestCheckTypeSetElemAttr("pinot_user.test", "components.*", "CONTROLLER"),
+				),
+			},
+			// Renaming the user replaces it
+			{
+				Config: providerConfig + `
+resource "pinot_user" "test" {
+	username   = "renamed_user"
+	password   = "password"
+	components = ["BROKER", "CONTROLLER"]
+	role       = "ADMIN"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pinot_user.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("pinot_user.test", "username", "renamed_user"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestPasswordMatches(t *testing.T) {
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !passwordMatches(string(hash), "password") {
+		t.Error("expected the password to match its hash")
+	}
+
+	if passwordMatches(string(hash), "rotated") {
+		t.Error("expected a rotated password not to match")
+	}
+
+	if !passwordMatches(string(hash), "") {
+		t.Error("expected a null password not to be checked")
+	}
+}